@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"game-server/internal/config"
 	"game-server/internal/handler"
 	"game-server/internal/pkg/auth"
@@ -10,13 +11,16 @@ import (
 	"game-server/internal/service"
 	"game-server/internal/socket"
 	"log"
+	"net/http"
+	"os/signal"
+	"syscall"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/joho/godotenv"
 )
 
-func setupRouter() *gin.Engine {
+func setupRouter(gameService *service.GameService, replayService *service.ReplayService, moderationService *service.ModerationService, sessionService *service.SessionService, friendService *service.FriendService) *gin.Engine {
 	router := gin.Default()
 
 	// 404 에러 처리
@@ -24,10 +28,13 @@ func setupRouter() *gin.Engine {
 		response.Error(context, errors.NotFound())
 	})
 
-	// 서비스 및 핸들러 초기화
-	gameService := service.NewGameService()
-	gameHandler := handler.NewGameHandler(gameService)
+	// 핸들러 초기화
+	gameHandler := handler.NewGameHandler(gameService, replayService)
 	gameHandler.RegisterRoutes(router)
+	adminHandler := handler.NewAdminHandler(moderationService, sessionService)
+	adminHandler.RegisterRoutes(router)
+	friendHandler := handler.NewFriendHandler(friendService)
+	friendHandler.RegisterRoutes(router)
 
 	// 기본 엔드포인트
 	router.GET("/", func(context *gin.Context) {
@@ -60,15 +67,30 @@ func main() {
 		log.Fatalf("Failed to initialize Redis: %v", err)
 	}
 
+	// 이벤트 저장소 초기화 (DynamoDB 또는 로컬 파일)
+	if err := database.InitEventStore(cfg); err != nil {
+		log.Fatalf("Failed to initialize event store: %v", err)
+	}
+
 	// JWT 공개키 초기화
 	if err := auth.InitJWT(); err != nil {
 		log.Fatalf("Failed to initialize JWT: %v", err)
 	}
 	log.Println("JWT public key initialized successfully")
 
-	// Match 서비스 및 서버 초기화
-	matchService := service.NewMatchService()
-	matchServer := socket.NewMatchServer(matchService)
+	// 토큰 폐기 및 사용자 차단 검사 등록
+	moderationService := service.NewModerationService()
+	auth.RegisterClaimsCheck(moderationService.CheckClaims)
+
+	// Match/Game 서비스 및 서버 초기화
+	sessionService := service.NewSessionService(cfg.Socket.SessionPolicy)
+	friendService := service.NewFriendService()
+	matchService := service.NewMatchService(sessionService, friendService)
+	eventRecorder := service.NewEventRecorder()
+	gameService := service.NewGameService(eventRecorder)
+	replayService := service.NewReplayService()
+	presenceService := service.NewPresenceService(sessionService, matchService, gameService)
+	matchServer := socket.NewMatchServer(cfg.Socket, matchService, gameService, replayService, sessionService, presenceService, friendService)
 	go func() {
 		if err := matchServer.Start(cfg.Server.MatchPort); err != nil {
 			log.Printf("Match server error: %v", err)
@@ -76,7 +98,53 @@ func main() {
 	}()
 
 	// HTTP 서버 시작
-	router := setupRouter()
-	log.Printf("HTTP Server starting on port %s", cfg.Server.HTTPPort)
-	router.Run(":" + cfg.Server.HTTPPort)
+	router := setupRouter(gameService, replayService, moderationService, sessionService, friendService)
+	httpServer := &http.Server{
+		Addr:    ":" + cfg.Server.HTTPPort,
+		Handler: router,
+	}
+	go func() {
+		log.Printf("HTTP Server starting on port %s", cfg.Server.HTTPPort)
+		if err := httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.Fatalf("HTTP server error: %v", err)
+		}
+	}()
+
+	// 종료 신호 대기
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+	<-ctx.Done()
+	stop()
+	log.Printf("Shutdown signal received, draining each stage for up to %s", cfg.Server.ShutdownTimeout)
+
+	// 1. 소켓/HTTP 요청 수신 중단 및 진행 중인 요청 처리 대기 (소켓 정리가 늦어도 HTTP는 따로 시간을 가짐)
+	shutdownStage("Match server", cfg.Server.ShutdownTimeout, func(ctx context.Context) error {
+		return matchServer.Shutdown(ctx, cfg.Server.ReconnectDelay)
+	})
+	shutdownStage("HTTP server", cfg.Server.ShutdownTimeout, httpServer.Shutdown)
+
+	// 2. 남은 게임 이벤트 저장 후 데이터 저장소 순서대로 종료
+	// 시간 초과로 남은 핸들러가 이후에 기록하는 이벤트는 버려짐 (Record가 에러 반환)
+	eventRecorder.Close()
+	if err := database.CloseEventStore(); err != nil {
+		log.Printf("Failed to close event store: %v", err)
+	}
+	if err := database.CloseRedis(); err != nil {
+		log.Printf("Failed to close Redis: %v", err)
+	}
+	if err := database.CloseMySQL(); err != nil {
+		log.Printf("Failed to close MySQL: %v", err)
+	}
+
+	log.Println("Server stopped")
+}
+
+// shutdownStage 종료 단계 하나를 자체 제한 시간 안에서 실행
+func shutdownStage(name string, timeout time.Duration, shutdown func(ctx context.Context) error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	if err := shutdown(ctx); err != nil {
+		log.Printf("%s shutdown error: %v", name, err)
+	}
 }
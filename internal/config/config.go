@@ -4,18 +4,24 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
+	"time"
 )
 
 // Config 애플리케이션 설정
 type Config struct {
 	Env    string
 	Server struct {
-		HTTPPort  string
-		MatchPort string
+		HTTPPort        string
+		MatchPort       string
+		ShutdownTimeout time.Duration // 종료 신호 후 단계별(소켓, HTTP)로 진행 중인 요청을 기다리는 최대 시간
+		ReconnectDelay  time.Duration // 종료 시 클라이언트에게 알려주는 재접속 대기 시간
 	}
-	WriterDB MySQLConfig
-	ReaderDB MySQLConfig
-	Redis    RedisConfig
+	WriterDB   MySQLConfig
+	ReaderDB   MySQLConfig
+	Redis      RedisConfig
+	EventStore EventStoreConfig
+	Socket     SocketConfig
 }
 
 // MySQLConfig MySQL 설정
@@ -46,6 +52,47 @@ type RedisConfig struct {
 	DB       int
 }
 
+// EventStoreConfig 게임 이벤트 저장소 설정
+type EventStoreConfig struct {
+	Driver string // "dynamodb" 또는 "file"
+	Path   string // file 드라이버의 저장 디렉터리
+}
+
+// SocketConfig 매치 소켓 서버 설정
+type SocketConfig struct {
+	MaxConnections      int           // 동시 연결 최대 수 (0이면 제한 없음)
+	MaxConnectionsPerIP int           // IP별 동시 연결 최대 수 (0이면 제한 없음)
+	AuthTimeout         time.Duration // 연결 후 auth를 완료해야 하는 시간
+	ProxyProtocol       bool          // 로드밸런서가 붙이는 PROXY 프로토콜 헤더 사용 (LB를 통해서만 접근 가능해야 함)
+	TLS                 TLSConfig
+	SessionPolicy       string // 같은 사용자가 다시 접속할 때: kick_old, reject_new, multi
+
+	RateLimits      map[string]RateLimitRule // 메시지 타입별 기본 제한 덮어쓰기
+	UnauthRateLimit RateLimitRule            // 인증 전 연결의 IP별 메시지 제한
+	MaxViolations   int                      // 이 횟수 이상 제한을 넘기면 연결 종료
+	ViolationWindow time.Duration            // 위반 횟수를 세는 기간
+}
+
+// TLSConfig 매치 소켓 TLS 설정 (CertFile이 비어 있으면 평문)
+type TLSConfig struct {
+	CertFile       string
+	KeyFile        string
+	MinVersion     string        // "1.2" 또는 "1.3"
+	ClientCAFile   string        // 설정하면 클라이언트 인증서를 제시한 연결(내부 게임 서버)은 이 CA로 검증
+	ReloadInterval time.Duration // 인증서 파일 변경 확인 주기
+}
+
+// Enabled TLS 사용 여부
+func (c *TLSConfig) Enabled() bool {
+	return c.CertFile != ""
+}
+
+// RateLimitRule 토큰 버킷 설정 (초당 Rate개 충전, 최대 Burst개, Rate가 0이면 제한 없음)
+type RateLimitRule struct {
+	Rate  float64
+	Burst int
+}
+
 // Load 환경에 따라 설정을 로드
 func Load() (*Config, error) {
 	cfg := &Config{
@@ -55,6 +102,8 @@ func Load() (*Config, error) {
 	// 서버 설정
 	cfg.Server.HTTPPort = getEnv("HTTP_PORT")
 	cfg.Server.MatchPort = getEnv("MATCH_PORT")
+	cfg.Server.ShutdownTimeout = getEnvAsDurationOrDefault("SHUTDOWN_TIMEOUT", 20*time.Second)
+	cfg.Server.ReconnectDelay = getEnvAsDurationOrDefault("SHUTDOWN_RECONNECT_DELAY", 2*time.Second)
 
 	// Writer DB 설정 (필수)
 	cfg.WriterDB.Host = getEnv("WRITER_DB_HOST")
@@ -76,6 +125,34 @@ func Load() (*Config, error) {
 	cfg.Redis.Password = getEnv("REDIS_PASSWORD")
 	cfg.Redis.DB = getEnvAsInt("REDIS_DB")
 
+	// 이벤트 저장소 설정 (선택, 기본값 DynamoDB)
+	cfg.EventStore.Driver = getEnvOrDefault("EVENT_STORE_DRIVER", "dynamodb")
+	cfg.EventStore.Path = getEnvOrDefault("EVENT_STORE_PATH", "data/events")
+
+	// 소켓 서버 설정 (선택)
+	cfg.Socket.MaxConnections = getEnvAsIntOrDefault("SOCKET_MAX_CONNECTIONS", 10000)
+	cfg.Socket.MaxConnectionsPerIP = getEnvAsIntOrDefault("SOCKET_MAX_CONNECTIONS_PER_IP", 50)
+	cfg.Socket.AuthTimeout = getEnvAsDurationOrDefault("SOCKET_AUTH_TIMEOUT", 10*time.Second)
+	cfg.Socket.ProxyProtocol = getEnvAsBoolOrDefault("SOCKET_PROXY_PROTOCOL", false)
+	cfg.Socket.TLS.CertFile = os.Getenv("SOCKET_TLS_CERT_FILE")
+	cfg.Socket.TLS.KeyFile = os.Getenv("SOCKET_TLS_KEY_FILE")
+	cfg.Socket.TLS.MinVersion = getEnvOrDefault("SOCKET_TLS_MIN_VERSION", "1.2")
+	cfg.Socket.TLS.ClientCAFile = os.Getenv("SOCKET_TLS_CLIENT_CA_FILE")
+	cfg.Socket.TLS.ReloadInterval = getEnvAsDurationOrDefault("SOCKET_TLS_RELOAD_INTERVAL", time.Minute)
+	if cfg.Socket.TLS.Enabled() && cfg.Socket.TLS.KeyFile == "" {
+		return nil, fmt.Errorf("SOCKET_TLS_KEY_FILE is required when SOCKET_TLS_CERT_FILE is set")
+	}
+	cfg.Socket.SessionPolicy = getEnvOrDefault("SOCKET_SESSION_POLICY", "kick_old")
+	switch cfg.Socket.SessionPolicy {
+	case "kick_old", "reject_new", "multi":
+	default:
+		return nil, fmt.Errorf("invalid SOCKET_SESSION_POLICY: %s (use kick_old, reject_new or multi)", cfg.Socket.SessionPolicy)
+	}
+	cfg.Socket.RateLimits = getEnvAsRateLimits("SOCKET_RATE_LIMITS")
+	cfg.Socket.UnauthRateLimit = parseRateLimitRule("SOCKET_UNAUTH_RATE_LIMIT", getEnvOrDefault("SOCKET_UNAUTH_RATE_LIMIT", "5:20"))
+	cfg.Socket.MaxViolations = getEnvAsIntOrDefault("SOCKET_MAX_RATE_LIMIT_VIOLATIONS", 20)
+	cfg.Socket.ViolationWindow = getEnvAsDurationOrDefault("SOCKET_RATE_LIMIT_VIOLATION_WINDOW", time.Minute)
+
 	return cfg, nil
 }
 
@@ -88,6 +165,88 @@ func getEnv(key string) string {
 	return value
 }
 
+// getEnvOrDefault 선택 환경변수 (없으면 기본값 반환)
+func getEnvOrDefault(key string, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
+
+// getEnvAsDurationOrDefault 선택 시간 환경변수 (예: 30s, 1m), 없으면 기본값 반환
+func getEnvAsDurationOrDefault(key string, defaultValue time.Duration) time.Duration {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		panic(fmt.Sprintf("invalid %s value: %v", key, err))
+	}
+	return duration
+}
+
+// getEnvAsIntOrDefault 선택 정수형 환경변수, 없으면 기본값 반환
+func getEnvAsIntOrDefault(key string, defaultValue int) int {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+	intValue, err := strconv.Atoi(value)
+	if err != nil {
+		panic(fmt.Sprintf("invalid %s value: %v", key, err))
+	}
+	return intValue
+}
+
+// getEnvAsBoolOrDefault 선택 bool 환경변수 (true/false, 1/0), 없으면 기본값 반환
+func getEnvAsBoolOrDefault(key string, defaultValue bool) bool {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+	boolValue, err := strconv.ParseBool(value)
+	if err != nil {
+		panic(fmt.Sprintf("invalid %s value: %v", key, err))
+	}
+	return boolValue
+}
+
+// getEnvAsRateLimits 메시지 타입별 제한 (예: "create_match=1:3,invite_friends=0.5:5")
+func getEnvAsRateLimits(key string) map[string]RateLimitRule {
+	rules := make(map[string]RateLimitRule)
+	value := os.Getenv(key)
+	if value == "" {
+		return rules
+	}
+
+	for _, entry := range strings.Split(value, ",") {
+		msgType, rule, ok := strings.Cut(strings.TrimSpace(entry), "=")
+		if !ok || msgType == "" {
+			panic(fmt.Sprintf("invalid %s entry: %q", key, entry))
+		}
+		rules[msgType] = parseRateLimitRule(key, rule)
+	}
+	return rules
+}
+
+// parseRateLimitRule "rate:burst" 형식 파싱
+func parseRateLimitRule(key string, value string) RateLimitRule {
+	rateValue, burstValue, ok := strings.Cut(value, ":")
+	if !ok {
+		panic(fmt.Sprintf("invalid %s value: %q (expected rate:burst)", key, value))
+	}
+	rate, err := strconv.ParseFloat(rateValue, 64)
+	if err != nil || rate < 0 {
+		panic(fmt.Sprintf("invalid %s rate: %q", key, rateValue))
+	}
+	burst, err := strconv.Atoi(burstValue)
+	if err != nil || burst < 0 {
+		panic(fmt.Sprintf("invalid %s burst: %q", key, burstValue))
+	}
+	return RateLimitRule{Rate: rate, Burst: burst}
+}
+
 // getEnvAsInt 필수 정수형 환경변수 (없거나 잘못된 값이면 에러 반환)
 func getEnvAsInt(key string) int {
 	value := getEnv(key)
@@ -0,0 +1,48 @@
+package config
+
+import "testing"
+
+func TestParseRateLimitRule(t *testing.T) {
+	rule := parseRateLimitRule("TEST", "0.5:5")
+	if rule.Rate != 0.5 || rule.Burst != 5 {
+		t.Fatalf("rule = %+v", rule)
+	}
+
+	for _, value := range []string{"", "1", "x:3", "1:x", "-1:3", "1:-3"} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%q should be rejected", value)
+				}
+			}()
+			parseRateLimitRule("TEST", value)
+		}()
+	}
+}
+
+func TestGetEnvAsRateLimits(t *testing.T) {
+	t.Setenv("TEST_RATE_LIMITS", "create_match=1:3, invite_friends=0.5:5")
+	rules := getEnvAsRateLimits("TEST_RATE_LIMITS")
+	if len(rules) != 2 {
+		t.Fatalf("rules = %+v", rules)
+	}
+	if rules["create_match"] != (RateLimitRule{Rate: 1, Burst: 3}) {
+		t.Fatalf("create_match = %+v", rules["create_match"])
+	}
+	if rules["invite_friends"] != (RateLimitRule{Rate: 0.5, Burst: 5}) {
+		t.Fatalf("invite_friends = %+v", rules["invite_friends"])
+	}
+
+	t.Setenv("TEST_RATE_LIMITS", "")
+	if rules := getEnvAsRateLimits("TEST_RATE_LIMITS"); len(rules) != 0 {
+		t.Fatalf("unset value should give no rules, got %+v", rules)
+	}
+
+	t.Setenv("TEST_RATE_LIMITS", "=1:3")
+	defer func() {
+		if recover() == nil {
+			t.Fatal("entry without a message type should be rejected")
+		}
+	}()
+	getEnvAsRateLimits("TEST_RATE_LIMITS")
+}
@@ -0,0 +1,62 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Friendship 친구 관계 (요청자 -> 대상, 수락 전에는 pending)
+// 두 사용자 사이에는 방향과 상관없이 하나만 존재하도록 (LowUserID, HighUserID)에 유니크 인덱스
+type Friendship struct {
+	ID          string     `json:"id" gorm:"primaryKey"`
+	RequesterID string     `json:"requester_id" gorm:"index"`
+	AddresseeID string     `json:"addressee_id" gorm:"index"`
+	LowUserID   string     `json:"-" gorm:"uniqueIndex:idx_friendship_users"`
+	HighUserID  string     `json:"-" gorm:"uniqueIndex:idx_friendship_users"`
+	Status      string     `json:"status"` // pending, accepted
+	AcceptedAt  *time.Time `json:"accepted_at"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+// BeforeCreate UUID 자동 생성, 정렬된 사용자 쌍 설정
+func (f *Friendship) BeforeCreate(tx *gorm.DB) error {
+	if f.ID == "" {
+		f.ID = uuid.New().String()
+	}
+	f.LowUserID, f.HighUserID = FriendshipPair(f.RequesterID, f.AddresseeID)
+	return nil
+}
+
+// FriendshipPair 두 사용자 ID를 정렬해서 반환 (Friendship의 LowUserID, HighUserID)
+func FriendshipPair(userID, otherID string) (string, string) {
+	if userID < otherID {
+		return userID, otherID
+	}
+	return otherID, userID
+}
+
+// UserBlock 사용자 차단 (UserID가 BlockedID를 차단)
+type UserBlock struct {
+	ID        string    `json:"id" gorm:"primaryKey"`
+	UserID    string    `json:"user_id" gorm:"uniqueIndex:idx_user_block_pair"`
+	BlockedID string    `json:"blocked_id" gorm:"uniqueIndex:idx_user_block_pair;index"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// BeforeCreate UUID 자동 생성
+func (b *UserBlock) BeforeCreate(tx *gorm.DB) error {
+	if b.ID == "" {
+		b.ID = uuid.New().String()
+	}
+	return nil
+}
+
+// FriendSettings 친구 관련 개인 설정 (행이 없으면 기본값)
+type FriendSettings struct {
+	UserID           string    `json:"user_id" gorm:"primaryKey"`
+	AllowInvitesFrom string    `json:"allow_invites_from"` // friends, everyone, nobody
+	UpdatedAt        time.Time `json:"updated_at"`
+}
@@ -0,0 +1,17 @@
+package domain
+
+import "testing"
+
+func TestFriendshipPairIsOrderIndependent(t *testing.T) {
+	lowA, highA := FriendshipPair("user-b", "user-a")
+	lowB, highB := FriendshipPair("user-a", "user-b")
+	if lowA != "user-a" || highA != "user-b" || lowA != lowB || highA != highB {
+		t.Fatalf("pairs = (%s, %s) and (%s, %s)", lowA, highA, lowB, highB)
+	}
+
+	friendship := &Friendship{RequesterID: "user-b", AddresseeID: "user-a"}
+	friendship.BeforeCreate(nil)
+	if friendship.LowUserID != "user-a" || friendship.HighUserID != "user-b" {
+		t.Fatalf("friendship pair = (%s, %s)", friendship.LowUserID, friendship.HighUserID)
+	}
+}
@@ -0,0 +1,13 @@
+package domain
+
+// GameEvent 게임 이벤트 엔티티 (DynamoDB 저장용)
+// gameId(파티션 키) + eventId(정렬 키)로 게임별 순서가 보장됨
+type GameEvent struct {
+	GameID    string      `json:"gameId" dynamodbav:"gameId"`
+	EventID   string      `json:"eventId" dynamodbav:"eventId"`
+	Sequence  int64       `json:"sequence" dynamodbav:"sequence"`
+	Type      string      `json:"type" dynamodbav:"type"`
+	UserID    string      `json:"userId,omitempty" dynamodbav:"userId,omitempty"`
+	Data      interface{} `json:"data,omitempty" dynamodbav:"data,omitempty"`
+	Timestamp int64       `json:"timestamp" dynamodbav:"timestamp"` // 서버 기준 unix milliseconds
+}
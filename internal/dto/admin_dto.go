@@ -0,0 +1,26 @@
+package dto
+
+import "time"
+
+// BanUserRequest 사용자 차단 요청
+type BanUserRequest struct {
+	UserID          string `json:"userId" binding:"required"`
+	Reason          string `json:"reason" binding:"required"`
+	DurationSeconds int64  `json:"durationSeconds"` // 0이면 영구 차단
+}
+
+// BanInfo 사용자 차단 정보
+type BanInfo struct {
+	UserID    string     `json:"userId"`
+	Reason    string     `json:"reason"`
+	BannedBy  string     `json:"bannedBy"`
+	BannedAt  time.Time  `json:"bannedAt"`
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+}
+
+// RevokeTokenRequest 토큰/세션 폐기 요청
+type RevokeTokenRequest struct {
+	JTI       string `json:"jti"`
+	SessionID string `json:"sessionId"`
+	ExpiresAt int64  `json:"expiresAt"` // 토큰 만료 시각 (unix), 폐기 기록의 TTL 기준
+}
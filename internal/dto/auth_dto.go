@@ -0,0 +1,77 @@
+package dto
+
+import "time"
+
+// SocketAuthRequest 소켓 인증(auth) 요청
+type SocketAuthRequest struct {
+	Token           string   `json:"token"`
+	ProtocolVersion int      `json:"protocolVersion"`
+	ClientBuild     string   `json:"clientBuild"`
+	Capabilities    []string `json:"capabilities"` // 클라이언트가 지원하는 기능
+	Device          string   `json:"device"`       // pc, console, mobile (없으면 pc)
+}
+
+// SocketAuthResponse 소켓 인증 성공 응답 (협상된 프로토콜 정보 포함)
+type SocketAuthResponse struct {
+	UserID             string   `json:"userId"`
+	ProtocolVersion    int      `json:"protocolVersion"`
+	MinProtocolVersion int      `json:"minProtocolVersion"`
+	MaxProtocolVersion int      `json:"maxProtocolVersion"`
+	Capabilities       []string `json:"capabilities"` // 이 연결에서 사용할 기능
+	Device             string   `json:"device"`
+	DeviceCapabilities []string `json:"deviceCapabilities"` // 기기 종류에 따라 허용되는 기능 (can_play 등)
+}
+
+// UpgradeRequiredResponse 지원 범위를 벗어난 클라이언트에게 보내는 업데이트 안내
+type UpgradeRequiredResponse struct {
+	ClientVersion      int    `json:"clientVersion"`
+	MinProtocolVersion int    `json:"minProtocolVersion"`
+	MaxProtocolVersion int    `json:"maxProtocolVersion"`
+	Message            string `json:"message"`
+}
+
+// ReauthRequest 토큰 갱신 요청
+type ReauthRequest struct {
+	Token string `json:"token"`
+}
+
+// ReauthResponse 토큰 갱신 응답
+type ReauthResponse struct {
+	UserID    string `json:"userId"`
+	ExpiresAt int64  `json:"expiresAt"`
+}
+
+// TokenExpiringResponse 토큰 만료 임박 알림
+type TokenExpiringResponse struct {
+	ExpiresAt int64  `json:"expiresAt"`
+	Message   string `json:"message"`
+}
+
+// SessionReplaced 새 연결이 기존 세션을 대체했을 때 모든 서버 노드에 알리는 메시지
+type SessionReplaced struct {
+	UserID   string `json:"userId"`
+	Device   string `json:"device"`   // 같은 기기 종류의 세션만 교체
+	SocketID string `json:"socketId"` // 유지할 새 연결 (나머지 연결은 종료)
+}
+
+// SessionReplacedNotice 다른 곳에서 로그인해 연결이 종료된다는 알림
+type SessionReplacedNotice struct {
+	Message string `json:"message"`
+}
+
+// SessionInfo 사용자의 소켓 세션 하나 (기기 종류와 기능)
+type SessionInfo struct {
+	SocketID     string    `json:"socketId"`
+	Device       string    `json:"device"`
+	Capabilities []string  `json:"capabilities"`
+	ConnectedAt  time.Time `json:"connectedAt"`
+}
+
+// SessionPresence 사용자의 모든 세션을 합친 접속 상태
+type SessionPresence struct {
+	UserID       string        `json:"userId"`
+	Online       bool          `json:"online"`
+	Devices      []string      `json:"devices"`
+	Capabilities []string      `json:"capabilities"`
+	Sessions     []SessionInfo `json:"sessions"`
+}
@@ -0,0 +1,57 @@
+package dto
+
+import "time"
+
+// FriendTargetRequest 친구 요청/수락/거절/삭제, 차단/해제 대상
+type FriendTargetRequest struct {
+	UserID string `json:"userId" binding:"required"`
+}
+
+// FriendRequestResponse 친구 요청 결과 (상대가 먼저 요청했다면 바로 accepted)
+type FriendRequestResponse struct {
+	UserID string `json:"userId"`
+	Status string `json:"status"` // pending, accepted
+}
+
+// FriendInfo 친구 목록 항목
+type FriendInfo struct {
+	UserID string    `json:"userId"`
+	Since  time.Time `json:"since"`
+}
+
+// FriendRequestInfo 대기 중인 친구 요청
+type FriendRequestInfo struct {
+	UserID    string    `json:"userId"`
+	Direction string    `json:"direction"` // incoming, outgoing
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// FriendListResponse 친구 목록과 대기 중인 요청
+type FriendListResponse struct {
+	Friends  []FriendInfo        `json:"friends"`
+	Requests []FriendRequestInfo `json:"requests"`
+}
+
+// BlockInfo 차단 목록 항목
+type BlockInfo struct {
+	UserID    string    `json:"userId"`
+	BlockedAt time.Time `json:"blockedAt"`
+}
+
+// FriendSettingsRequest 친구 설정 변경 요청
+type FriendSettingsRequest struct {
+	AllowInvitesFrom string `json:"allowInvitesFrom" binding:"required"` // friends, everyone, nobody
+}
+
+// FriendSettingsResponse 친구 설정
+type FriendSettingsResponse struct {
+	AllowInvitesFrom string `json:"allowInvitesFrom"`
+}
+
+// FriendEvent 친구 관련 실시간 알림 (모든 서버 노드에 발행 후 대상 사용자에게 전달)
+type FriendEvent struct {
+	Type       string    `json:"type"`       // friend_request_received, friend_request_accepted, friend_removed
+	UserID     string    `json:"userId"`     // 알림을 받을 사용자
+	FromUserID string    `json:"fromUserId"` // 상대 사용자
+	CreatedAt  time.Time `json:"createdAt"`
+}
@@ -0,0 +1,57 @@
+package dto
+
+// GameEventQueryRequest 게임 이벤트 조회 요청 (query string)
+type GameEventQueryRequest struct {
+	Types  string `form:"types"` // 콤마로 구분된 이벤트 타입
+	From   string `form:"from"`  // RFC3339
+	To     string `form:"to"`    // RFC3339
+	Order  string `form:"order"` // "asc" or "desc"
+	Limit  int    `form:"limit"`
+	Cursor string `form:"cursor"`
+}
+
+// ReplayStartRequest 리플레이 시작 요청
+type ReplayStartRequest struct {
+	GameID string  `json:"gameId"`
+	Speed  float64 `json:"speed,omitempty"` // 기본 1배속
+}
+
+// ReplayControlRequest 리플레이 제어 요청
+type ReplayControlRequest struct {
+	Action     string  `json:"action"` // "pause", "resume", "seek", "speed"
+	PositionMs int64   `json:"positionMs,omitempty"`
+	Speed      float64 `json:"speed,omitempty"`
+}
+
+// ReplayStartedResponse 리플레이 시작 응답
+type ReplayStartedResponse struct {
+	GameID     string  `json:"gameId"`
+	DurationMs int64   `json:"durationMs"`
+	EventCount int     `json:"eventCount"`
+	Speed      float64 `json:"speed"`
+}
+
+// ReplayState 특정 시점까지 이벤트를 적용해 재구성한 게임 상태
+type ReplayState struct {
+	GameID        string         `json:"gameId"`
+	PositionMs    int64          `json:"positionMs"`
+	AppliedEvents int            `json:"appliedEvents"`
+	LastEventID   string         `json:"lastEventId,omitempty"`
+	Game          *GameInfo      `json:"game,omitempty"`
+	EventCounts   map[string]int `json:"eventCounts"`
+}
+
+// ReplayEvent 리플레이 중 전달되는 이벤트
+type ReplayEvent struct {
+	GameID     string      `json:"gameId"`
+	PositionMs int64       `json:"positionMs"`
+	EventID    string      `json:"eventId"`
+	Type       string      `json:"type"`
+	UserID     string      `json:"userId,omitempty"`
+	Data       interface{} `json:"data,omitempty"`
+}
+
+// ReplayQueryRequest 리플레이 상태 조회 요청 (query string)
+type ReplayQueryRequest struct {
+	At *int64 `form:"at"` // 시작 기준 ms, 없으면 마지막 상태
+}
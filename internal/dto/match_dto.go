@@ -6,6 +6,7 @@ import "time"
 type CreateMatchRequest struct {
 	GameID     string `json:"gameId"`
 	MaxPlayers int    `json:"maxPlayers"`
+	Public     bool   `json:"public"` // 공개 로비 목록에 노출하고 초대 없이 참가 허용
 }
 
 // CreateMatchResponse 매칭 생성 응답
@@ -14,6 +15,7 @@ type CreateMatchResponse struct {
 	GameID     string `json:"gameId"`
 	HostID     string `json:"hostId"`
 	MaxPlayers int    `json:"maxPlayers"`
+	Public     bool   `json:"public"`
 	Message    string `json:"message"`
 }
 
@@ -55,6 +57,21 @@ type InviteResponseResponse struct {
 	Message  string `json:"message"`
 }
 
+// ListLobbiesRequest 공개 로비 목록 요청
+type ListLobbiesRequest struct {
+	GameID string `json:"gameId,omitempty"` // 비어 있으면 모든 게임
+}
+
+// LobbyListResponse 공개 로비 목록
+type LobbyListResponse struct {
+	Lobbies []MatchInfo `json:"lobbies"`
+}
+
+// JoinLobbyRequest 공개 로비 참가 요청
+type JoinLobbyRequest struct {
+	MatchID string `json:"matchId"`
+}
+
 // MatchInfo 매칭 정보
 type MatchInfo struct {
 	MatchID    string        `json:"matchId"`
@@ -63,6 +80,7 @@ type MatchInfo struct {
 	Status     string        `json:"status"` // "waiting", "ready", "starting", "playing"
 	Players    []MatchPlayer `json:"players"`
 	MaxPlayers int           `json:"maxPlayers"`
+	Public     bool          `json:"public"`
 	CreatedAt  time.Time     `json:"createdAt"`
 	StartedAt  *time.Time    `json:"startedAt,omitempty"`
 }
@@ -124,11 +142,19 @@ type PlayerDisconnectedResponse struct {
 	TeamID   int    `json:"teamId"`
 }
 
+// ServerShutdownNotice 서버 종료 알림 (재접속 힌트 포함)
+type ServerShutdownNotice struct {
+	Message          string `json:"message"`
+	ReconnectAfterMs int64  `json:"reconnectAfterMs"` // 이 시간이 지난 후 재접속 권장
+}
+
 // ========== 공통 DTO ==========
 
 // ErrorResponse 에러 응답
 type ErrorResponse struct {
-	Message string `json:"message"`
+	Code    string                 `json:"code"`
+	Message string                 `json:"message"`
+	Details map[string]interface{} `json:"details,omitempty"`
 }
 
 // SuccessResponse 성공 응답
@@ -0,0 +1,40 @@
+package dto
+
+import "time"
+
+// Presence 사용자 접속 상태 (online, in_lobby, in_game, away, offline)
+type Presence struct {
+	UserID    string            `json:"userId"`
+	State     string            `json:"state"`
+	Devices   []string          `json:"devices"` // 접속 중인 기기 종류 (모든 세션의 합집합)
+	Activity  *PresenceActivity `json:"activity,omitempty"`
+	UpdatedAt time.Time         `json:"updatedAt"`
+}
+
+// PresenceActivity 로비/게임 중일 때의 상세 상태
+type PresenceActivity struct {
+	GameID      string `json:"gameId"`
+	PlayerCount int    `json:"playerCount"`
+	MaxPlayers  int    `json:"maxPlayers,omitempty"`
+}
+
+// SubscribePresenceRequest 접속 상태 구독 요청
+type SubscribePresenceRequest struct {
+	UserIDs []string `json:"userIds"`
+}
+
+// SubscribePresenceResponse 구독한 사용자들의 현재 상태
+type SubscribePresenceResponse struct {
+	Presences []Presence `json:"presences"`
+	Rejected  []string   `json:"rejected,omitempty"` // 친구가 아니거나 차단 관계라서 구독하지 않은 사용자
+}
+
+// UnsubscribePresenceRequest 접속 상태 구독 해제 요청 (비어 있으면 전체 해제)
+type UnsubscribePresenceRequest struct {
+	UserIDs []string `json:"userIds"`
+}
+
+// SetPresenceRequest 자리 비움 설정 요청
+type SetPresenceRequest struct {
+	Away bool `json:"away"`
+}
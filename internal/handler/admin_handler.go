@@ -0,0 +1,108 @@
+package handler
+
+import (
+	"expvar"
+	"game-server/internal/dto"
+	"game-server/internal/middleware"
+	"game-server/internal/pkg/auth"
+	"game-server/internal/pkg/errors"
+	"game-server/internal/pkg/response"
+	"game-server/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AdminHandler struct {
+	moderationService *service.ModerationService
+	sessionService    *service.SessionService
+}
+
+func NewAdminHandler(moderationService *service.ModerationService, sessionService *service.SessionService) *AdminHandler {
+	return &AdminHandler{
+		moderationService: moderationService,
+		sessionService:    sessionService,
+	}
+}
+
+func (handler *AdminHandler) RegisterRoutes(router *gin.Engine) {
+	// 운영자 API
+	admin := router.Group("/admin")
+	{
+		admin.Use(middleware.JwtAuth(), middleware.RequireRole(auth.ROLE_ADMIN))
+		admin.POST("/bans", middleware.RequireScope(auth.SCOPE_BAN), handler.BanUser)
+		admin.GET("/bans/:userId", handler.GetBan)
+		admin.DELETE("/bans/:userId", middleware.RequireScope(auth.SCOPE_BAN), handler.UnbanUser)
+		admin.POST("/revocations", handler.RevokeToken)
+		admin.GET("/sessions/:userId", handler.GetSessions)
+		admin.GET("/metrics", gin.WrapH(expvar.Handler()))
+	}
+}
+
+// BanUser 사용자 차단 (접속 중인 소켓은 즉시 끊김)
+func (handler *AdminHandler) BanUser(context *gin.Context) {
+	var req dto.BanUserRequest
+	if err := context.ShouldBindJSON(&req); err != nil {
+		response.Error(context, errors.InvalidInput())
+		return
+	}
+
+	ban, err := handler.moderationService.BanUser(&req, context.GetString("userId"))
+	if err != nil {
+		response.Error(context, err)
+		return
+	}
+
+	response.Success(context, ban)
+}
+
+// GetBan 사용자 차단 정보 조회
+func (handler *AdminHandler) GetBan(context *gin.Context) {
+	ban, err := handler.moderationService.GetBan(context.Param("userId"))
+	if err != nil {
+		response.Error(context, errors.DBError())
+		return
+	}
+	if ban == nil {
+		response.Error(context, errors.NotFound())
+		return
+	}
+
+	response.Success(context, ban)
+}
+
+// UnbanUser 사용자 차단 해제
+func (handler *AdminHandler) UnbanUser(context *gin.Context) {
+	if err := handler.moderationService.UnbanUser(context.Param("userId")); err != nil {
+		response.Error(context, err)
+		return
+	}
+
+	response.Success(context, dto.SuccessResponse{Message: "Ban lifted"})
+}
+
+// RevokeToken 토큰(jti) 또는 세션 폐기 (해당 토큰으로 접속 중인 소켓은 즉시 끊김)
+func (handler *AdminHandler) RevokeToken(context *gin.Context) {
+	var req dto.RevokeTokenRequest
+	if err := context.ShouldBindJSON(&req); err != nil {
+		response.Error(context, errors.InvalidInput())
+		return
+	}
+
+	if err := handler.moderationService.RevokeToken(&req); err != nil {
+		response.Error(context, err)
+		return
+	}
+
+	response.Success(context, dto.SuccessResponse{Message: "Token revoked"})
+}
+
+// GetSessions 사용자의 접속 기기와 세션 목록 조회
+func (handler *AdminHandler) GetSessions(context *gin.Context) {
+	presence, err := handler.sessionService.Presence(context.Param("userId"))
+	if err != nil {
+		response.Error(context, err)
+		return
+	}
+
+	response.Success(context, presence)
+}
@@ -0,0 +1,163 @@
+package handler
+
+import (
+	"game-server/internal/dto"
+	"game-server/internal/middleware"
+	"game-server/internal/pkg/errors"
+	"game-server/internal/pkg/response"
+	"game-server/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type FriendHandler struct {
+	friendService *service.FriendService
+}
+
+func NewFriendHandler(friendService *service.FriendService) *FriendHandler {
+	return &FriendHandler{
+		friendService: friendService,
+	}
+}
+
+func (handler *FriendHandler) RegisterRoutes(router *gin.Engine) {
+	// 친구 API (로그인한 사용자 본인 기준)
+	friends := router.Group("/friends")
+	{
+		friends.Use(middleware.JwtAuth())
+		friends.GET("", handler.ListFriends)
+		friends.DELETE("/:userId", handler.RemoveFriend)
+		friends.POST("/requests", handler.SendRequest)
+		friends.POST("/requests/:userId/accept", handler.AcceptRequest)
+		friends.POST("/requests/:userId/decline", handler.DeclineRequest)
+		friends.GET("/blocks", handler.ListBlocks)
+		friends.POST("/blocks", handler.Block)
+		friends.DELETE("/blocks/:userId", handler.Unblock)
+		friends.GET("/settings", handler.GetSettings)
+		friends.PUT("/settings", handler.UpdateSettings)
+	}
+}
+
+// ListFriends 친구 목록과 대기 중인 요청 조회
+func (handler *FriendHandler) ListFriends(context *gin.Context) {
+	friends, err := handler.friendService.ListFriends(context.GetString("userId"))
+	if err != nil {
+		response.Error(context, err)
+		return
+	}
+
+	response.Success(context, friends)
+}
+
+// SendRequest 친구 요청 (상대가 이미 요청했다면 바로 친구가 됨)
+func (handler *FriendHandler) SendRequest(context *gin.Context) {
+	var req dto.FriendTargetRequest
+	if err := context.ShouldBindJSON(&req); err != nil {
+		response.Error(context, errors.InvalidInput())
+		return
+	}
+
+	result, err := handler.friendService.SendRequest(context.GetString("userId"), req.UserID)
+	if err != nil {
+		response.Error(context, err)
+		return
+	}
+
+	response.Success(context, result)
+}
+
+// AcceptRequest 받은 친구 요청 수락
+func (handler *FriendHandler) AcceptRequest(context *gin.Context) {
+	friend, err := handler.friendService.AcceptRequest(context.GetString("userId"), context.Param("userId"))
+	if err != nil {
+		response.Error(context, err)
+		return
+	}
+
+	response.Success(context, friend)
+}
+
+// DeclineRequest 받은 친구 요청 거절
+func (handler *FriendHandler) DeclineRequest(context *gin.Context) {
+	if err := handler.friendService.DeclineRequest(context.GetString("userId"), context.Param("userId")); err != nil {
+		response.Error(context, err)
+		return
+	}
+
+	response.Success(context, dto.SuccessResponse{Message: "Friend request declined"})
+}
+
+// RemoveFriend 친구 삭제 또는 보낸 요청 취소
+func (handler *FriendHandler) RemoveFriend(context *gin.Context) {
+	if err := handler.friendService.RemoveFriend(context.GetString("userId"), context.Param("userId")); err != nil {
+		response.Error(context, err)
+		return
+	}
+
+	response.Success(context, dto.SuccessResponse{Message: "Friend removed"})
+}
+
+// ListBlocks 차단 목록 조회
+func (handler *FriendHandler) ListBlocks(context *gin.Context) {
+	blocks, err := handler.friendService.ListBlocks(context.GetString("userId"))
+	if err != nil {
+		response.Error(context, err)
+		return
+	}
+
+	response.Success(context, blocks)
+}
+
+// Block 사용자 차단 (친구 관계와 대기 중인 요청도 삭제)
+func (handler *FriendHandler) Block(context *gin.Context) {
+	var req dto.FriendTargetRequest
+	if err := context.ShouldBindJSON(&req); err != nil {
+		response.Error(context, errors.InvalidInput())
+		return
+	}
+
+	if err := handler.friendService.Block(context.GetString("userId"), req.UserID); err != nil {
+		response.Error(context, err)
+		return
+	}
+
+	response.Success(context, dto.SuccessResponse{Message: "User blocked"})
+}
+
+// Unblock 차단 해제
+func (handler *FriendHandler) Unblock(context *gin.Context) {
+	if err := handler.friendService.Unblock(context.GetString("userId"), context.Param("userId")); err != nil {
+		response.Error(context, err)
+		return
+	}
+
+	response.Success(context, dto.SuccessResponse{Message: "User unblocked"})
+}
+
+// GetSettings 친구 설정 조회
+func (handler *FriendHandler) GetSettings(context *gin.Context) {
+	settings, err := handler.friendService.GetSettings(context.GetString("userId"))
+	if err != nil {
+		response.Error(context, err)
+		return
+	}
+
+	response.Success(context, settings)
+}
+
+// UpdateSettings 친구 설정 변경 (매치 초대를 받을 상대)
+func (handler *FriendHandler) UpdateSettings(context *gin.Context) {
+	var req dto.FriendSettingsRequest
+	if err := context.ShouldBindJSON(&req); err != nil {
+		response.Error(context, errors.InvalidInput())
+		return
+	}
+
+	settings, err := handler.friendService.UpdateSettings(context.GetString("userId"), &req)
+	if err != nil {
+		response.Error(context, err)
+		return
+	}
+
+	response.Success(context, settings)
+}
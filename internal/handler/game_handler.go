@@ -1,19 +1,25 @@
 package handler
 
 import (
+	"game-server/internal/dto"
 	"game-server/internal/middleware"
+	"game-server/internal/pkg/auth"
+	"game-server/internal/pkg/errors"
+	"game-server/internal/pkg/response"
 	"game-server/internal/service"
 
 	"github.com/gin-gonic/gin"
 )
 
 type GameHandler struct {
-	gameService *service.GameService
+	gameService   *service.GameService
+	replayService *service.ReplayService
 }
 
-func NewGameHandler(gameService *service.GameService) *GameHandler {
+func NewGameHandler(gameService *service.GameService, replayService *service.ReplayService) *GameHandler {
 	return &GameHandler{
-		gameService: gameService,
+		gameService:   gameService,
+		replayService: replayService,
 	}
 }
 
@@ -22,5 +28,48 @@ func (handler *GameHandler) RegisterRoutes(router *gin.Engine) {
 	games := router.Group("/games")
 	{
 		games.Use(middleware.JwtAuth())
+		// 이벤트 로그와 리플레이 상태는 운영자/분석가만 조회
+		sessions := games.Group("/sessions", middleware.RequireRole(auth.ROLE_ADMIN, auth.ROLE_ANALYST))
+		sessions.GET("/:id/events", handler.GetSessionEvents)
+		sessions.GET("/:id/replay", handler.GetSessionReplay)
 	}
 }
+
+// GetSessionEvents 게임 세션의 이벤트 로그 조회 (분석용)
+func (handler *GameHandler) GetSessionEvents(context *gin.Context) {
+	var req dto.GameEventQueryRequest
+	if err := context.ShouldBindQuery(&req); err != nil {
+		response.Error(context, errors.InvalidInput())
+		return
+	}
+
+	page, err := handler.gameService.QueryEvents(context.Param("id"), &req)
+	if err != nil {
+		response.Error(context, err)
+		return
+	}
+
+	response.Success(context, page)
+}
+
+// GetSessionReplay 이벤트 로그로 재구성한 게임 세션 상태 조회 (분쟁 처리용)
+func (handler *GameHandler) GetSessionReplay(context *gin.Context) {
+	var req dto.ReplayQueryRequest
+	if err := context.ShouldBindQuery(&req); err != nil {
+		response.Error(context, errors.InvalidInput())
+		return
+	}
+
+	replay, err := handler.replayService.LoadReplay(context.Param("id"))
+	if err != nil {
+		response.Error(context, err)
+		return
+	}
+
+	position := replay.Duration()
+	if req.At != nil {
+		position = *req.At
+	}
+
+	response.Success(context, replay.StateAt(position))
+}
@@ -0,0 +1,58 @@
+package middleware
+
+import (
+	"game-server/internal/pkg/auth"
+	"game-server/internal/pkg/errors"
+	"game-server/internal/pkg/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequireRole 주어진 역할 중 하나를 가진 사용자만 허용 (JwtAuth 뒤에 사용)
+func RequireRole(roles ...string) gin.HandlerFunc {
+	return func(context *gin.Context) {
+		claims := getClaims(context)
+		if claims == nil {
+			response.Error(context, errors.Unauthorized())
+			context.Abort()
+			return
+		}
+
+		if !claims.HasAnyRole(roles...) {
+			response.Error(context, errors.Forbidden())
+			context.Abort()
+			return
+		}
+
+		context.Next()
+	}
+}
+
+// RequireScope 주어진 스코프를 모두 가진 토큰만 허용 (JwtAuth 뒤에 사용)
+func RequireScope(scopes ...string) gin.HandlerFunc {
+	return func(context *gin.Context) {
+		claims := getClaims(context)
+		if claims == nil {
+			response.Error(context, errors.Unauthorized())
+			context.Abort()
+			return
+		}
+
+		if !claims.HasAllScopes(scopes...) {
+			response.Error(context, errors.Forbidden())
+			context.Abort()
+			return
+		}
+
+		context.Next()
+	}
+}
+
+func getClaims(context *gin.Context) *auth.Claims {
+	value, exists := context.Get("claims")
+	if !exists {
+		return nil
+	}
+	claims, _ := value.(*auth.Claims)
+	return claims
+}
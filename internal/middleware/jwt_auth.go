@@ -27,31 +27,24 @@ func JwtAuth() gin.HandlerFunc {
 			return
 		}
 
-		// 토큰 검증
-		token, err := auth.ValidateAccessToken(tokenString)
+		// 토큰 및 클레임 검증
+		claims, err := auth.ValidateAccessToken(tokenString)
 		if err != nil {
-			response.Error(context, errors.Unauthorized())
-			context.Abort()
-			return
-		}
-
-		// 토큰이 유효한지 확인
-		if !token.Valid {
-			response.Error(context, errors.Unauthorized())
-			context.Abort()
-			return
-		}
-
-		// 사용자 ID 추출
-		userID, err := auth.GetUserIDFromToken(token)
-		if err != nil {
-			response.Error(context, errors.Unauthorized())
+			// 폐기/차단처럼 사유가 있는 에러는 그대로 전달
+			if appErr, ok := err.(*errors.AppError); ok {
+				response.Error(context, appErr)
+			} else {
+				response.Error(context, errors.Unauthorized())
+			}
 			context.Abort()
 			return
 		}
 
-		// 컨텍스트에 사용자 ID 저장
-		context.Set("userId", userID)
+		// 컨텍스트에 사용자 ID, 역할, 스코프와 클레임 저장
+		context.Set("userId", claims.UserID)
+		context.Set("roles", claims.Roles)
+		context.Set("scopes", claims.Scopes)
+		context.Set("claims", claims)
 		context.Next()
 	}
 }
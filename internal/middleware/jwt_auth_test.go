@@ -0,0 +1,161 @@
+package middleware
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"game-server/internal/pkg/auth"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	testKid      = "test-key"
+	testIssuer   = "https://auth.test"
+	testAudience = "game-server"
+)
+
+// setupTestJWT JWKS 파일에 테스트 키를 등록하고 auth 패키지를 초기화한 뒤 서명 키를 반환
+func setupTestJWT(t *testing.T) ed25519.PrivateKey {
+	t.Helper()
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	jwks, err := json.Marshal(map[string]interface{}{
+		"keys": []map[string]string{{
+			"kty": "OKP",
+			"kid": testKid,
+			"use": "sig",
+			"crv": "Ed25519",
+			"x":   base64.RawURLEncoding.EncodeToString(publicKey),
+		}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	if err := os.WriteFile(path, jwks, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv("JWT_JWKS_PATH", path)
+	t.Setenv("JWT_ISSUER", testIssuer)
+	t.Setenv("JWT_AUDIENCE", testAudience)
+	if err := auth.InitJWT(); err != nil {
+		t.Fatalf("InitJWT: %v", err)
+	}
+	return privateKey
+}
+
+func validClaims() jwt.MapClaims {
+	now := time.Now()
+	return jwt.MapClaims{
+		"userId": "user-1",
+		"roles":  []string{auth.ROLE_ADMIN},
+		"iss":    testIssuer,
+		"aud":    testAudience,
+		"iat":    now.Unix(),
+		"exp":    now.Add(time.Hour).Unix(),
+	}
+}
+
+func signToken(t *testing.T, key ed25519.PrivateKey, kid string, claims jwt.MapClaims) string {
+	t.Helper()
+	token := jwt.NewWithClaims(jwt.SigningMethodEdDSA, claims)
+	token.Header["kid"] = kid
+	signed, err := token.SignedString(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signed
+}
+
+// serveWithJwtAuth JwtAuth를 거친 요청의 상태 코드와 핸들러에 전달된 사용자 ID 반환
+func serveWithJwtAuth(t *testing.T, token string) (int, string) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	var userID string
+	router.GET("/protected", JwtAuth(), func(context *gin.Context) {
+		userID = context.GetString("userId")
+		context.Status(http.StatusOK)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/protected", nil)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	return rec.Code, userID
+}
+
+func TestJwtAuthAcceptsValidToken(t *testing.T) {
+	key := setupTestJWT(t)
+
+	code, userID := serveWithJwtAuth(t, signToken(t, key, testKid, validClaims()))
+	if code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", code)
+	}
+	if userID != "user-1" {
+		t.Fatalf("userId = %q, want user-1", userID)
+	}
+}
+
+func TestJwtAuthRejectsInvalidTokens(t *testing.T) {
+	key := setupTestJWT(t)
+	_, otherKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	without := func(claim string) jwt.MapClaims {
+		claims := validClaims()
+		delete(claims, claim)
+		return claims
+	}
+
+	cases := []struct {
+		name  string
+		token string
+	}{
+		{"missing header", ""},
+		{"unknown kid", signToken(t, key, "other-key", validClaims())},
+		{"wrong signing key", signToken(t, otherKey, testKid, validClaims())},
+		{"missing userId", signToken(t, key, testKid, without("userId"))},
+		{"missing exp", signToken(t, key, testKid, without("exp"))},
+		{"missing issuer", signToken(t, key, testKid, without("iss"))},
+		{"missing audience", signToken(t, key, testKid, without("aud"))},
+	}
+	for _, tc := range cases {
+		if code, _ := serveWithJwtAuth(t, tc.token); code != http.StatusUnauthorized {
+			t.Errorf("%s: status = %d, want 401", tc.name, code)
+		}
+	}
+}
+
+func TestJwtAuthRejectsExpiredToken(t *testing.T) {
+	key := setupTestJWT(t)
+
+	claims := validClaims()
+	claims["iat"] = time.Now().Add(-2 * time.Hour).Unix()
+	claims["exp"] = time.Now().Add(-time.Hour).Unix() // 허용 오차(30초)를 넘겨 만료
+	if code, _ := serveWithJwtAuth(t, signToken(t, key, testKid, claims)); code != http.StatusUnauthorized {
+		t.Fatalf("expired token: status = %d, want 401", code)
+	}
+
+	claims["exp"] = time.Now().Add(-5 * time.Second).Unix() // 허용 오차 안쪽
+	if code, _ := serveWithJwtAuth(t, signToken(t, key, testKid, claims)); code != http.StatusOK {
+		t.Fatalf("token within leeway: status = %d, want 200", code)
+	}
+}
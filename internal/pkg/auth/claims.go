@@ -0,0 +1,43 @@
+package auth
+
+import (
+	"slices"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// 권한 확인에 쓰는 역할과 스코프 (HTTP 미들웨어와 소켓 라우트가 함께 사용)
+const (
+	ROLE_ADMIN   = "admin"
+	ROLE_ANALYST = "analyst"
+	SCOPE_BAN    = "moderation:ban"
+)
+
+// Claims Auth 서버가 발급한 액세스 토큰의 클레임
+type Claims struct {
+	UserID    string   `json:"userId"`
+	Roles     []string `json:"roles,omitempty"`
+	Scopes    []string `json:"scopes,omitempty"`
+	SessionID string   `json:"sid,omitempty"`
+	jwt.RegisteredClaims
+}
+
+// HasAnyRole 역할 중 하나라도 보유했는지 확인
+func (c *Claims) HasAnyRole(roles ...string) bool {
+	for _, role := range roles {
+		if slices.Contains(c.Roles, role) {
+			return true
+		}
+	}
+	return false
+}
+
+// HasAllScopes 모든 스코프를 보유했는지 확인
+func (c *Claims) HasAllScopes(scopes ...string) bool {
+	for _, scope := range scopes {
+		if !slices.Contains(c.Scopes, scope) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,293 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"math/big"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+const (
+	DEFAULT_JWKS_REFRESH_INTERVAL = 5 * time.Minute
+	DEFAULT_KEY_GRACE_PERIOD      = time.Hour
+
+	// 모르는 kid로 인한 즉시 갱신의 최소 간격
+	jwksMinRefreshInterval = time.Minute
+	jwksFetchTimeout       = 10 * time.Second
+)
+
+// jwk JSON Web Key (RFC 7517)
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use,omitempty"`
+	Alg string `json:"alg,omitempty"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+	Y   string `json:"y,omitempty"`
+}
+
+type jwkSet struct {
+	Keys []jwk `json:"keys"`
+}
+
+type keyEntry struct {
+	key       crypto.PublicKey
+	removedAt time.Time // JWKS 문서에서 빠진 시각 (zero면 현재 키)
+}
+
+// keySet JWKS 문서에서 로드한 kid별 공개키 목록
+// 문서에서 빠진 키도 유예 기간 동안은 계속 허용해서 키 교체 중 발급된 토큰을 받아줌
+type keySet struct {
+	source      string // 파일 경로 또는 http(s) URL
+	gracePeriod time.Duration
+	httpClient  *http.Client
+
+	mu          sync.RWMutex
+	keys        map[string]*keyEntry
+	lastRefresh time.Time
+
+	refreshGroup singleflight.Group // 동시에 들어온 갱신 요청을 한 번의 조회로 합침
+}
+
+func newKeySet(source string, gracePeriod time.Duration) *keySet {
+	return &keySet{
+		source:      source,
+		gracePeriod: gracePeriod,
+		httpClient:  &http.Client{Timeout: jwksFetchTimeout},
+		keys:        make(map[string]*keyEntry),
+	}
+}
+
+// lookup kid에 해당하는 키 조회, 없으면 JWKS를 한 번 다시 읽어봄
+func (ks *keySet) lookup(kid string) (crypto.PublicKey, error) {
+	if key, ok := ks.get(kid); ok {
+		return key, nil
+	}
+
+	if err := ks.refreshForUnknownKid(); err != nil {
+		log.Printf("Failed to refresh JWKS for unknown kid %s: %v", kid, err)
+	}
+	if key, ok := ks.get(kid); ok {
+		return key, nil
+	}
+
+	return nil, fmt.Errorf("unknown key id: %s", kid)
+}
+
+// refreshForUnknownKid 모르는 kid로 인한 갱신 (임의의 kid를 담은 토큰이 몰려도 조회는 최소 간격마다 한 번)
+func (ks *keySet) refreshForUnknownKid() error {
+	_, err, _ := ks.refreshGroup.Do("jwks", func() (interface{}, error) {
+		ks.mu.RLock()
+		recentlyRefreshed := time.Since(ks.lastRefresh) < jwksMinRefreshInterval
+		ks.mu.RUnlock()
+
+		if recentlyRefreshed {
+			return nil, nil
+		}
+		return nil, ks.refresh()
+	})
+	return err
+}
+
+func (ks *keySet) get(kid string) (crypto.PublicKey, bool) {
+	ks.mu.RLock()
+	defer ks.mu.RUnlock()
+
+	entry, ok := ks.keys[kid]
+	if !ok {
+		return nil, false
+	}
+	if !entry.removedAt.IsZero() && time.Since(entry.removedAt) > ks.gracePeriod {
+		return nil, false
+	}
+	return entry.key, true
+}
+
+// refresh JWKS 문서를 다시 읽어서 키 목록 갱신
+func (ks *keySet) refresh() error {
+	data, err := ks.fetch()
+
+	ks.mu.Lock()
+	defer ks.mu.Unlock()
+	ks.lastRefresh = time.Now()
+
+	if err != nil {
+		return err
+	}
+
+	var set jwkSet
+	if err := json.Unmarshal(data, &set); err != nil {
+		return fmt.Errorf("failed to parse JWKS: %w", err)
+	}
+
+	current := make(map[string]crypto.PublicKey)
+	for _, key := range set.Keys {
+		if key.Use != "" && key.Use != "sig" {
+			continue
+		}
+		if key.Kid == "" {
+			log.Printf("Skipping JWKS key without kid")
+			continue
+		}
+		publicKey, err := parseJWK(&key)
+		if err != nil {
+			log.Printf("Skipping JWKS key %s: %v", key.Kid, err)
+			continue
+		}
+		current[key.Kid] = publicKey
+	}
+	if len(current) == 0 {
+		return fmt.Errorf("JWKS contains no usable signing keys")
+	}
+
+	now := time.Now()
+	for kid, entry := range ks.keys {
+		if _, ok := current[kid]; ok {
+			continue
+		}
+		if entry.removedAt.IsZero() {
+			entry.removedAt = now
+			log.Printf("JWT key %s removed from JWKS, accepted for %s more", kid, ks.gracePeriod)
+		}
+		if now.Sub(entry.removedAt) > ks.gracePeriod {
+			delete(ks.keys, kid)
+		}
+	}
+	for kid, publicKey := range current {
+		if _, ok := ks.keys[kid]; !ok {
+			log.Printf("JWT key %s loaded from JWKS", kid)
+		}
+		ks.keys[kid] = &keyEntry{key: publicKey}
+	}
+
+	return nil
+}
+
+func (ks *keySet) fetch() ([]byte, error) {
+	if !strings.HasPrefix(ks.source, "http://") && !strings.HasPrefix(ks.source, "https://") {
+		data, err := os.ReadFile(ks.source)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read JWKS file: %w", err)
+		}
+		return data, nil
+	}
+
+	resp, err := ks.httpClient.Get(ks.source)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch JWKS: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch JWKS: status %d", resp.StatusCode)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read JWKS response: %w", err)
+	}
+	return data, nil
+}
+
+// refreshLoop 주기적으로 JWKS 갱신 (실패하면 기존 키 유지)
+func (ks *keySet) refreshLoop(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		if err := ks.refresh(); err != nil {
+			log.Printf("Failed to refresh JWKS: %v", err)
+		}
+	}
+}
+
+func parseJWK(key *jwk) (crypto.PublicKey, error) {
+	switch key.Kty {
+	case "RSA":
+		n, err := base64.RawURLEncoding.DecodeString(key.N)
+		if err != nil {
+			return nil, fmt.Errorf("invalid RSA modulus: %w", err)
+		}
+		e, err := base64.RawURLEncoding.DecodeString(key.E)
+		if err != nil {
+			return nil, fmt.Errorf("invalid RSA exponent: %w", err)
+		}
+		return &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}, nil
+	case "EC":
+		return parseECJWK(key)
+	case "OKP":
+		if key.Crv != "Ed25519" {
+			return nil, fmt.Errorf("unsupported OKP curve: %s", key.Crv)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(key.X)
+		if err != nil || len(x) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("invalid Ed25519 public key")
+		}
+		return ed25519.PublicKey(x), nil
+	default:
+		return nil, fmt.Errorf("unsupported key type: %s", key.Kty)
+	}
+}
+
+func parseECJWK(key *jwk) (crypto.PublicKey, error) {
+	var curve elliptic.Curve
+	var ecdhCurve ecdh.Curve
+	switch key.Crv {
+	case "P-256":
+		curve, ecdhCurve = elliptic.P256(), ecdh.P256()
+	case "P-384":
+		curve, ecdhCurve = elliptic.P384(), ecdh.P384()
+	case "P-521":
+		curve, ecdhCurve = elliptic.P521(), ecdh.P521()
+	default:
+		return nil, fmt.Errorf("unsupported EC curve: %s", key.Crv)
+	}
+
+	x, err := base64.RawURLEncoding.DecodeString(key.X)
+	if err != nil {
+		return nil, fmt.Errorf("invalid EC x coordinate: %w", err)
+	}
+	y, err := base64.RawURLEncoding.DecodeString(key.Y)
+	if err != nil {
+		return nil, fmt.Errorf("invalid EC y coordinate: %w", err)
+	}
+
+	// 좌표가 곡선 위의 점인지 확인
+	size := (curve.Params().BitSize + 7) / 8
+	if len(x) > size || len(y) > size {
+		return nil, fmt.Errorf("invalid EC point")
+	}
+	point := make([]byte, 1+2*size)
+	point[0] = 4
+	copy(point[1+size-len(x):1+size], x)
+	copy(point[1+2*size-len(y):], y)
+	if _, err := ecdhCurve.NewPublicKey(point); err != nil {
+		return nil, fmt.Errorf("invalid EC point: %w", err)
+	}
+
+	return &ecdsa.PublicKey{
+		Curve: curve,
+		X:     new(big.Int).SetBytes(x),
+		Y:     new(big.Int).SetBytes(y),
+	}, nil
+}
@@ -0,0 +1,191 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func testKeys(t *testing.T) map[string]crypto.PublicKey {
+	t.Helper()
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	edKey, _, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return map[string]crypto.PublicKey{
+		"rsa": &rsaKey.PublicKey,
+		"ec":  &ecKey.PublicKey,
+		"ed":  edKey,
+	}
+}
+
+func TestParseJWKRoundTrip(t *testing.T) {
+	for kid, publicKey := range testKeys(t) {
+		key, err := toJWK(kid, publicKey)
+		if err != nil {
+			t.Fatalf("%s: %v", kid, err)
+		}
+		parsed, err := parseJWK(key)
+		if err != nil {
+			t.Fatalf("%s: %v", kid, err)
+		}
+		if !parsed.(interface{ Equal(crypto.PublicKey) bool }).Equal(publicKey) {
+			t.Errorf("%s: parsed key does not match", kid)
+		}
+	}
+}
+
+func TestParseJWKRejectsInvalidECPoint(t *testing.T) {
+	key := &jwk{
+		Kty: "EC",
+		Crv: "P-256",
+		X:   base64.RawURLEncoding.EncodeToString([]byte{1}),
+		Y:   base64.RawURLEncoding.EncodeToString([]byte{2}),
+	}
+	if _, err := parseJWK(key); err == nil {
+		t.Fatal("point off the curve should be rejected")
+	}
+}
+
+func TestKeySetUnknownKidRefreshIsSingleFlight(t *testing.T) {
+	var fetches atomic.Int32
+	server := httptest.NewServer(newJWKSHandler(testKeys(t), &fetches))
+	defer server.Close()
+
+	keys := newKeySet(server.URL, time.Hour)
+	if err := keys.refresh(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := keys.lookup("rsa"); err != nil {
+		t.Fatal(err)
+	}
+
+	// 최소 간격이 지난 뒤 임의의 kid가 한꺼번에 들어와도 한 번만 다시 조회
+	keys.mu.Lock()
+	keys.lastRefresh = time.Now().Add(-2 * jwksMinRefreshInterval)
+	keys.mu.Unlock()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if _, err := keys.lookup(fmt.Sprintf("random-%d", i)); err == nil {
+				t.Errorf("random kid %d should be unknown", i)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if got := fetches.Load(); got != 2 {
+		t.Fatalf("JWKS fetched %d times, want 2", got)
+	}
+}
+
+func TestKeySetKeepsRemovedKeyDuringGracePeriod(t *testing.T) {
+	all := testKeys(t)
+	served := map[string]crypto.PublicKey{"rsa": all["rsa"], "ec": all["ec"]}
+	var fetches atomic.Int32
+	var mu sync.Mutex
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		newJWKSHandler(served, &fetches).ServeHTTP(w, r)
+	}))
+	defer server.Close()
+
+	keys := newKeySet(server.URL, time.Hour)
+	if err := keys.refresh(); err != nil {
+		t.Fatal(err)
+	}
+
+	mu.Lock()
+	delete(served, "ec")
+	mu.Unlock()
+	if err := keys.refresh(); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := keys.get("ec"); !ok {
+		t.Fatal("removed key should be accepted during the grace period")
+	}
+
+	keys.mu.Lock()
+	keys.keys["ec"].removedAt = time.Now().Add(-2 * time.Hour)
+	keys.mu.Unlock()
+	if _, ok := keys.get("ec"); ok {
+		t.Fatal("removed key should be rejected after the grace period")
+	}
+}
+
+// newJWKSHandler 주어진 키로 JWKS 문서를 제공하는 테스트용 HTTP 핸들러 (요청마다 fetches 증가)
+func newJWKSHandler(keys map[string]crypto.PublicKey, fetches *atomic.Int32) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fetches.Add(1)
+		set := jwkSet{Keys: []jwk{}}
+		for kid, publicKey := range keys {
+			key, err := toJWK(kid, publicKey)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+			set.Keys = append(set.Keys, *key)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(set)
+	})
+}
+
+func toJWK(kid string, publicKey crypto.PublicKey) (*jwk, error) {
+	switch key := publicKey.(type) {
+	case *rsa.PublicKey:
+		return &jwk{
+			Kty: "RSA",
+			Kid: kid,
+			Use: "sig",
+			N:   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}, nil
+	case *ecdsa.PublicKey:
+		size := (key.Curve.Params().BitSize + 7) / 8
+		return &jwk{
+			Kty: "EC",
+			Kid: kid,
+			Use: "sig",
+			Crv: key.Curve.Params().Name,
+			X:   base64.RawURLEncoding.EncodeToString(key.X.FillBytes(make([]byte, size))),
+			Y:   base64.RawURLEncoding.EncodeToString(key.Y.FillBytes(make([]byte, size))),
+		}, nil
+	case ed25519.PublicKey:
+		return &jwk{
+			Kty: "OKP",
+			Kid: kid,
+			Use: "sig",
+			Crv: "Ed25519",
+			X:   base64.RawURLEncoding.EncodeToString(key),
+		}, nil
+	default:
+		return nil, fmt.Errorf("unsupported key type: %T", publicKey)
+	}
+}
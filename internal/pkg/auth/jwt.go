@@ -1,35 +1,123 @@
 package auth
 
 import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
 	"crypto/rsa"
 	"crypto/x509"
 	"encoding/base64"
 	"encoding/pem"
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 )
 
-var publicKey *rsa.PublicKey
+const DEFAULT_JWT_LEEWAY = 30 * time.Second
+
+// 허용하는 서명 알고리즘 (RSA, RSA-PSS, ECDSA, EdDSA)
+var supportedAlgorithms = []string{
+	"RS256", "RS384", "RS512",
+	"PS256", "PS384", "PS512",
+	"ES256", "ES384", "ES512",
+	"EdDSA",
+}
+
+// ClaimsCheck 서명 검증 이후에 적용할 추가 검사 (토큰 폐기, 사용자 차단 등)
+type ClaimsCheck func(claims *Claims) error
+
+var (
+	claimsChecks []ClaimsCheck
+	publicKey    crypto.PublicKey // kid 없는 토큰용 고정 키 (JWT_PUBLIC_KEY)
+	jwks         *keySet          // kid별 키 (JWT_JWKS_URL 또는 JWT_JWKS_PATH)
+	parser       = newParser("", "", DEFAULT_JWT_LEEWAY)
+)
 
 // InitJWT JWT 공개키를 초기화합니다
+// JWT_PUBLIC_KEY(고정 키)와 JWKS(키 교체 지원) 중 하나 이상이 필요합니다
 func InitJWT() error {
-	publicKeyPEM := os.Getenv("JWT_PUBLIC_KEY")
-	if publicKeyPEM == "" {
-		return fmt.Errorf("JWT_PUBLIC_KEY environment variable is required")
+	leeway, err := getEnvAsDuration("JWT_LEEWAY", DEFAULT_JWT_LEEWAY)
+	if err != nil {
+		return err
+	}
+	parser = newParser(os.Getenv("JWT_ISSUER"), os.Getenv("JWT_AUDIENCE"), leeway)
+
+	if publicKeyPEM := os.Getenv("JWT_PUBLIC_KEY"); publicKeyPEM != "" {
+		key, err := parsePublicKey(publicKeyPEM)
+		if err != nil {
+			return fmt.Errorf("failed to parse JWT public key: %w", err)
+		}
+		publicKey = key
+	}
+
+	source := os.Getenv("JWT_JWKS_URL")
+	if source == "" {
+		source = os.Getenv("JWT_JWKS_PATH")
+	}
+	if source == "" {
+		if publicKey == nil {
+			return fmt.Errorf("JWT_PUBLIC_KEY or JWT_JWKS_URL/JWT_JWKS_PATH environment variable is required")
+		}
+		return nil
 	}
 
-	key, err := parsePublicKey(publicKeyPEM)
+	refreshInterval, err := getEnvAsDuration("JWT_JWKS_REFRESH_INTERVAL", DEFAULT_JWKS_REFRESH_INTERVAL)
+	if err != nil {
+		return err
+	}
+	gracePeriod, err := getEnvAsDuration("JWT_KEY_GRACE_PERIOD", DEFAULT_KEY_GRACE_PERIOD)
 	if err != nil {
-		return fmt.Errorf("failed to parse JWT public key: %w", err)
+		return err
 	}
 
-	publicKey = key
+	keys := newKeySet(source, gracePeriod)
+	if err := keys.refresh(); err != nil {
+		return fmt.Errorf("failed to load JWKS: %w", err)
+	}
+	jwks = keys
+	go keys.refreshLoop(refreshInterval)
+
 	return nil
 }
 
-func parsePublicKey(publicKeyPEM string) (*rsa.PublicKey, error) {
+// RegisterClaimsCheck 모든 토큰 검증에 적용할 추가 검사 등록
+// HTTP 미들웨어와 소켓 인증 모두 ValidateAccessToken을 거치므로 한 번만 등록하면 됨
+func RegisterClaimsCheck(check ClaimsCheck) {
+	claimsChecks = append(claimsChecks, check)
+}
+
+// newParser 발급자/대상/시간 오차를 검증하는 파서 생성 (빈 값이면 해당 검증 생략)
+func newParser(issuer, audience string, leeway time.Duration) *jwt.Parser {
+	options := []jwt.ParserOption{
+		jwt.WithValidMethods(supportedAlgorithms),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+		jwt.WithLeeway(leeway),
+	}
+	if issuer != "" {
+		options = append(options, jwt.WithIssuer(issuer))
+	}
+	if audience != "" {
+		options = append(options, jwt.WithAudience(audience))
+	}
+	return jwt.NewParser(options...)
+}
+
+func getEnvAsDuration(key string, defaultValue time.Duration) (time.Duration, error) {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue, nil
+	}
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration < 0 {
+		return 0, fmt.Errorf("invalid %s value: %s", key, value)
+	}
+	return duration, nil
+}
+
+func parsePublicKey(publicKeyPEM string) (crypto.PublicKey, error) {
 	// Base64 디코딩
 	decodedKey, err := base64.StdEncoding.DecodeString(publicKeyPEM)
 	if err != nil {
@@ -46,40 +134,73 @@ func parsePublicKey(publicKeyPEM string) (*rsa.PublicKey, error) {
 		return nil, fmt.Errorf("failed to parse public key: %w", err)
 	}
 
-	rsaKey, ok := pub.(*rsa.PublicKey)
-	if !ok {
-		return nil, fmt.Errorf("key is not RSA public key")
+	switch pub.(type) {
+	case *rsa.PublicKey, *ecdsa.PublicKey, ed25519.PublicKey:
+		return pub, nil
+	default:
+		return nil, fmt.Errorf("unsupported public key type: %T", pub)
 	}
-
-	return rsaKey, nil
 }
 
-// ValidateAccessToken Auth 서버에서 발급된 JWT 토큰을 공개키로 검증합니다
-func ValidateAccessToken(tokenString string) (*jwt.Token, error) {
-	if publicKey == nil {
+// ValidateAccessToken Auth 서버에서 발급된 JWT 토큰을 공개키로 검증하고 클레임을 반환합니다
+func ValidateAccessToken(tokenString string) (*Claims, error) {
+	if publicKey == nil && jwks == nil {
 		return nil, fmt.Errorf("JWT public key not initialized")
 	}
 
-	return jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-		// RSA 서명 방식인지 확인
-		if _, ok := token.Method.(*jwt.SigningMethodRSA); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+	claims := &Claims{}
+	token, err := parser.ParseWithClaims(tokenString, claims, resolveKey)
+	if err != nil {
+		return nil, err
+	}
+	if !token.Valid {
+		return nil, fmt.Errorf("invalid token")
+	}
+
+	// 필수 클레임 확인
+	if claims.UserID == "" {
+		return nil, fmt.Errorf("userId not found in token")
+	}
+
+	for _, check := range claimsChecks {
+		if err := check(claims); err != nil {
+			return nil, err
 		}
-		return publicKey, nil
-	})
+	}
+
+	return claims, nil
 }
 
-// GetUserIDFromToken 토큰에서 사용자 ID를 추출합니다
-func GetUserIDFromToken(token *jwt.Token) (string, error) {
-	claims, ok := token.Claims.(jwt.MapClaims)
-	if !ok {
-		return "", fmt.Errorf("invalid token claims")
+// resolveKey 토큰 헤더의 kid로 검증 키 선택 (kid가 없으면 고정 키)
+func resolveKey(token *jwt.Token) (interface{}, error) {
+	var key crypto.PublicKey
+	kid, _ := token.Header["kid"].(string)
+	if kid == "" || jwks == nil {
+		if publicKey == nil {
+			return nil, fmt.Errorf("token has no kid and no static public key is configured")
+		}
+		key = publicKey
+	} else {
+		found, err := jwks.lookup(kid)
+		if err != nil {
+			return nil, err
+		}
+		key = found
 	}
 
-	userID, ok := claims["userId"].(string)
-	if !ok {
-		return "", fmt.Errorf("userId not found in token")
+	// 서명 방식과 키 종류가 맞는지 확인
+	var matches bool
+	switch token.Method.(type) {
+	case *jwt.SigningMethodRSA, *jwt.SigningMethodRSAPSS:
+		_, matches = key.(*rsa.PublicKey)
+	case *jwt.SigningMethodECDSA:
+		_, matches = key.(*ecdsa.PublicKey)
+	case *jwt.SigningMethodEd25519:
+		_, matches = key.(ed25519.PublicKey)
+	}
+	if !matches {
+		return nil, fmt.Errorf("unexpected signing method %v for key %s", token.Header["alg"], kid)
 	}
 
-	return userID, nil
+	return key, nil
 }
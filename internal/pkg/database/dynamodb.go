@@ -2,19 +2,33 @@ package database
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"game-server/internal/domain"
 	"log"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/aws/smithy-go"
 )
 
 var dynamoClient *dynamodb.Client
 
+const (
+	// DYNAMODB_BATCH_SIZE BatchWriteItem 한 번에 쓸 수 있는 최대 아이템 수
+	DYNAMODB_BATCH_SIZE = 25
+
+	dynamoMaxRetries     = 5
+	dynamoRetryBaseDelay = 100 * time.Millisecond
+)
+
 // InitDynamoDB DynamoDB 클라이언트 초기화
 // 게임 이벤트 저장용, DynamoDB Stream으로 S3에 백업
 func InitDynamoDB() error {
@@ -116,7 +130,83 @@ func SaveEventToDynamoDB(event interface{}) error {
 	return nil
 }
 
+// BatchSaveEventsToDynamoDB 게임 이벤트를 BatchWriteItem으로 묶어서 저장
+// 스로틀링이나 미처리 아이템은 지수 백오프로 재시도
+func BatchSaveEventsToDynamoDB(events []interface{}) error {
+	if dynamoClient == nil {
+		return fmt.Errorf("DynamoDB client not initialized")
+	}
+
+	tableName := getEnv("DYNAMODB_TABLE_NAME", "game-events")
+
+	for start := 0; start < len(events); start += DYNAMODB_BATCH_SIZE {
+		end := start + DYNAMODB_BATCH_SIZE
+		if end > len(events) {
+			end = len(events)
+		}
+
+		requests := make([]types.WriteRequest, 0, end-start)
+		for _, event := range events[start:end] {
+			item, err := attributevalue.MarshalMap(event)
+			if err != nil {
+				return fmt.Errorf("failed to marshal event: %w", err)
+			}
+			requests = append(requests, types.WriteRequest{
+				PutRequest: &types.PutRequest{Item: item},
+			})
+		}
+
+		if err := batchWriteWithRetry(tableName, requests); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func batchWriteWithRetry(tableName string, requests []types.WriteRequest) error {
+	pending := map[string][]types.WriteRequest{tableName: requests}
+
+	for attempt := 0; ; attempt++ {
+		result, err := dynamoClient.BatchWriteItem(context.TODO(), &dynamodb.BatchWriteItemInput{
+			RequestItems: pending,
+		})
+		if err != nil && !isThrottlingError(err) {
+			return fmt.Errorf("failed to batch write to DynamoDB: %w", err)
+		}
+		if err == nil {
+			if len(result.UnprocessedItems[tableName]) == 0 {
+				return nil
+			}
+			pending = result.UnprocessedItems
+		}
+
+		if attempt >= dynamoMaxRetries {
+			return fmt.Errorf("failed to batch write to DynamoDB: %d items unprocessed after %d retries",
+				len(pending[tableName]), dynamoMaxRetries)
+		}
+
+		time.Sleep(dynamoRetryBaseDelay << attempt)
+	}
+}
+
+// isThrottlingError 재시도 가능한 스로틀링 에러인지 확인
+func isThrottlingError(err error) bool {
+	var throughputErr *types.ProvisionedThroughputExceededException
+	var limitErr *types.RequestLimitExceeded
+	if errors.As(err, &throughputErr) || errors.As(err, &limitErr) {
+		return true
+	}
+
+	var apiErr smithy.APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.ErrorCode() == "ThrottlingException"
+	}
+	return false
+}
+
 // QueryEventsByGameID 특정 게임의 모든 이벤트 조회 (분석용)
+// 1MB 단위로 나뉜 Query 결과를 LastEvaluatedKey로 끝까지 이어서 읽음
 func QueryEventsByGameID(gameID string) ([]map[string]interface{}, error) {
 	if dynamoClient == nil {
 		return nil, fmt.Errorf("DynamoDB client not initialized")
@@ -132,23 +222,127 @@ func QueryEventsByGameID(gameID string) ([]map[string]interface{}, error) {
 		},
 	}
 
-	result, err := dynamoClient.Query(context.TODO(), input)
+	var events []map[string]interface{}
+	paginator := dynamodb.NewQueryPaginator(dynamoClient, input)
+	for paginator.HasMorePages() {
+		result, err := paginator.NextPage(context.TODO())
+		if err != nil {
+			return nil, fmt.Errorf("failed to query DynamoDB: %w", err)
+		}
+
+		for _, item := range result.Items {
+			var event map[string]interface{}
+			err := attributevalue.UnmarshalMap(item, &event)
+			if err != nil {
+				log.Printf("Failed to unmarshal item: %v", err)
+				continue
+			}
+			events = append(events, event)
+		}
+	}
+
+	return events, nil
+}
+
+// dynamoEventStore DynamoDB 기반 EventStore 구현
+type dynamoEventStore struct{}
+
+func (s *dynamoEventStore) Append(events []domain.GameEvent) error {
+	items := make([]interface{}, len(events))
+	for i, event := range events {
+		items[i] = event
+	}
+	return BatchSaveEventsToDynamoDB(items)
+}
+
+func (s *dynamoEventStore) QueryByGame(gameID string, query EventQuery) (*EventPage, error) {
+	if dynamoClient == nil {
+		return nil, fmt.Errorf("DynamoDB client not initialized")
+	}
+
+	cursorID, err := decodeEventCursor(query.Cursor)
 	if err != nil {
-		return nil, fmt.Errorf("failed to query DynamoDB: %w", err)
+		return nil, err
 	}
+	limit := normalizePageSize(query.Limit)
 
-	var events []map[string]interface{}
-	for _, item := range result.Items {
-		var event map[string]interface{}
-		err := attributevalue.UnmarshalMap(item, &event)
+	input := &dynamodb.QueryInput{
+		TableName:              aws.String(getEnv("DYNAMODB_TABLE_NAME", "game-events")),
+		KeyConditionExpression: aws.String("gameId = :gameId"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":gameId": &types.AttributeValueMemberS{Value: gameID},
+		},
+		ScanIndexForward: aws.Bool(!query.Descending),
+	}
+	applyEventFilter(input, &query)
+	if cursorID != "" {
+		input.ExclusiveStartKey = map[string]types.AttributeValue{
+			"gameId":  &types.AttributeValueMemberS{Value: gameID},
+			"eventId": &types.AttributeValueMemberS{Value: cursorID},
+		}
+	}
+
+	// 필터는 읽은 뒤에 적용되므로 limit을 채우거나 파티션 끝에 닿을 때까지 이어서 조회
+	page := &EventPage{Events: make([]domain.GameEvent, 0, limit)}
+	for {
+		input.Limit = aws.Int32(int32(limit - len(page.Events)))
+
+		result, err := dynamoClient.Query(context.TODO(), input)
 		if err != nil {
-			log.Printf("Failed to unmarshal item: %v", err)
-			continue
+			return nil, fmt.Errorf("failed to query DynamoDB: %w", err)
+		}
+
+		var events []domain.GameEvent
+		if err := attributevalue.UnmarshalListOfMaps(result.Items, &events); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal events: %w", err)
+		}
+		page.Events = append(page.Events, events...)
+
+		if len(result.LastEvaluatedKey) == 0 {
+			return page, nil
+		}
+		if len(page.Events) >= limit {
+			page.NextCursor = encodeEventCursor(page.Events[len(page.Events)-1].EventID)
+			return page, nil
 		}
-		events = append(events, event)
+		input.ExclusiveStartKey = result.LastEvaluatedKey
 	}
+}
 
-	return events, nil
+// applyEventFilter 타입/시간 조건을 FilterExpression으로 변환
+func applyEventFilter(input *dynamodb.QueryInput, query *EventQuery) {
+	var conditions []string
+	names := map[string]string{}
+
+	if len(query.Types) > 0 {
+		placeholders := make([]string, len(query.Types))
+		for i, eventType := range query.Types {
+			placeholder := fmt.Sprintf(":type%d", i)
+			placeholders[i] = placeholder
+			input.ExpressionAttributeValues[placeholder] = &types.AttributeValueMemberS{Value: eventType}
+		}
+		names["#type"] = "type"
+		conditions = append(conditions, fmt.Sprintf("#type IN (%s)", strings.Join(placeholders, ", ")))
+	}
+	if !query.From.IsZero() {
+		names["#ts"] = "timestamp"
+		input.ExpressionAttributeValues[":from"] = &types.AttributeValueMemberN{Value: strconv.FormatInt(query.From.UnixMilli(), 10)}
+		conditions = append(conditions, "#ts >= :from")
+	}
+	if !query.To.IsZero() {
+		names["#ts"] = "timestamp"
+		input.ExpressionAttributeValues[":to"] = &types.AttributeValueMemberN{Value: strconv.FormatInt(query.To.UnixMilli(), 10)}
+		conditions = append(conditions, "#ts <= :to")
+	}
+
+	if len(conditions) > 0 {
+		input.FilterExpression = aws.String(strings.Join(conditions, " AND "))
+		input.ExpressionAttributeNames = names
+	}
+}
+
+func (s *dynamoEventStore) Close() error {
+	return nil
 }
 
 func getEnv(key, defaultValue string) string {
@@ -0,0 +1,149 @@
+package database
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"game-server/internal/config"
+	"game-server/internal/domain"
+	"iter"
+	"log"
+	"slices"
+	"time"
+)
+
+const (
+	EVENT_STORE_DRIVER_DYNAMODB = "dynamodb"
+	EVENT_STORE_DRIVER_FILE     = "file"
+
+	DEFAULT_EVENT_PAGE_SIZE = 100
+	MAX_EVENT_PAGE_SIZE     = 1000
+)
+
+// EventStore 게임 이벤트 저장소
+type EventStore interface {
+	// Append 이벤트 추가 (게임별 eventId 순서대로 전달되어야 함)
+	Append(events []domain.GameEvent) error
+	// QueryByGame 게임의 이벤트를 eventId 순서로 필터링해서 페이지 단위 조회
+	QueryByGame(gameID string, query EventQuery) (*EventPage, error)
+	Close() error
+}
+
+// EventQuery 이벤트 조회 조건
+type EventQuery struct {
+	Types      []string  // 비어 있으면 모든 타입
+	From       time.Time // 서버 timestamp 하한 (포함, zero면 제한 없음)
+	To         time.Time // 서버 timestamp 상한 (포함, zero면 제한 없음)
+	Descending bool
+	Limit      int
+	Cursor     string // 이전 페이지의 NextCursor
+}
+
+// ErrInvalidCursor 커서 토큰을 해석할 수 없음
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// EventPage 이벤트 조회 결과 페이지
+type EventPage struct {
+	Events     []domain.GameEvent `json:"events"`
+	NextCursor string             `json:"nextCursor,omitempty"`
+}
+
+var eventStore EventStore
+
+// InitEventStore 설정된 드라이버로 이벤트 저장소 초기화
+func InitEventStore(cfg *config.Config) error {
+	switch cfg.EventStore.Driver {
+	case EVENT_STORE_DRIVER_DYNAMODB:
+		if err := InitDynamoDB(); err != nil {
+			return err
+		}
+		eventStore = &dynamoEventStore{}
+	case EVENT_STORE_DRIVER_FILE:
+		store, err := NewFileEventStore(cfg.EventStore.Path)
+		if err != nil {
+			return err
+		}
+		eventStore = store
+	default:
+		return fmt.Errorf("unknown event store driver: %s", cfg.EventStore.Driver)
+	}
+
+	log.Printf("Event store initialized (driver: %s)", cfg.EventStore.Driver)
+	return nil
+}
+
+// GetEventStore 이벤트 저장소 인스턴스 반환
+func GetEventStore() EventStore {
+	return eventStore
+}
+
+// CloseEventStore 이벤트 저장소 종료
+func CloseEventStore() error {
+	if eventStore != nil {
+		return eventStore.Close()
+	}
+	return nil
+}
+
+// StreamEvents 모든 페이지를 순서대로 읽어 이벤트를 하나씩 반환하는 iterator
+func StreamEvents(store EventStore, gameID string, query EventQuery) iter.Seq2[domain.GameEvent, error] {
+	return func(yield func(domain.GameEvent, error) bool) {
+		for {
+			page, err := store.QueryByGame(gameID, query)
+			if err != nil {
+				yield(domain.GameEvent{}, err)
+				return
+			}
+			for _, event := range page.Events {
+				if !yield(event, nil) {
+					return
+				}
+			}
+			if page.NextCursor == "" {
+				return
+			}
+			query.Cursor = page.NextCursor
+		}
+	}
+}
+
+// matches 조회 조건에 맞는 이벤트인지 확인
+func (q *EventQuery) matches(event *domain.GameEvent) bool {
+	if len(q.Types) > 0 && !slices.Contains(q.Types, event.Type) {
+		return false
+	}
+	if !q.From.IsZero() && event.Timestamp < q.From.UnixMilli() {
+		return false
+	}
+	if !q.To.IsZero() && event.Timestamp > q.To.UnixMilli() {
+		return false
+	}
+	return true
+}
+
+// normalizePageSize 페이지 크기를 허용 범위로 보정
+func normalizePageSize(limit int) int {
+	if limit <= 0 {
+		return DEFAULT_EVENT_PAGE_SIZE
+	}
+	if limit > MAX_EVENT_PAGE_SIZE {
+		return MAX_EVENT_PAGE_SIZE
+	}
+	return limit
+}
+
+// encodeEventCursor 마지막으로 반환한 eventId를 커서 토큰으로 변환
+func encodeEventCursor(eventID string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(eventID))
+}
+
+func decodeEventCursor(cursor string) (string, error) {
+	if cursor == "" {
+		return "", nil
+	}
+	eventID, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return "", ErrInvalidCursor
+	}
+	return string(eventID), nil
+}
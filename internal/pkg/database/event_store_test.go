@@ -0,0 +1,100 @@
+package database
+
+import (
+	"errors"
+	"game-server/internal/domain"
+	"testing"
+)
+
+func TestEventCursorRoundTrip(t *testing.T) {
+	for _, eventID := range []string{"0000000001", "game-1#42", "한글 id/+="} {
+		cursor := encodeEventCursor(eventID)
+		decoded, err := decodeEventCursor(cursor)
+		if err != nil {
+			t.Fatalf("decode %q: %v", cursor, err)
+		}
+		if decoded != eventID {
+			t.Fatalf("decoded %q, want %q", decoded, eventID)
+		}
+	}
+}
+
+func TestDecodeEventCursor(t *testing.T) {
+	if eventID, err := decodeEventCursor(""); err != nil || eventID != "" {
+		t.Fatalf("empty cursor = (%q, %v), want first page", eventID, err)
+	}
+	if _, err := decodeEventCursor("not a cursor!"); !errors.Is(err, ErrInvalidCursor) {
+		t.Fatalf("err = %v, want ErrInvalidCursor", err)
+	}
+}
+
+func TestNormalizePageSize(t *testing.T) {
+	cases := map[int]int{0: DEFAULT_EVENT_PAGE_SIZE, -1: DEFAULT_EVENT_PAGE_SIZE, 10: 10, MAX_EVENT_PAGE_SIZE + 1: MAX_EVENT_PAGE_SIZE}
+	for limit, want := range cases {
+		if got := normalizePageSize(limit); got != want {
+			t.Errorf("normalizePageSize(%d) = %d, want %d", limit, got, want)
+		}
+	}
+}
+
+func TestStreamEvents(t *testing.T) {
+	store := newTestFileEventStore(t)
+	var events []domain.GameEvent
+	for sequence := int64(1); sequence <= 7; sequence++ {
+		events = append(events, testEvent("game-1", sequence, "move"))
+	}
+	if err := store.Append(events); err != nil {
+		t.Fatal(err)
+	}
+
+	// 페이지 경계를 넘어 모든 이벤트를 순서대로 반환
+	var streamed []domain.GameEvent
+	for event, err := range StreamEvents(store, "game-1", EventQuery{Limit: 3}) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		streamed = append(streamed, event)
+	}
+	if len(streamed) != 7 || streamed[0].Sequence != 1 || streamed[6].Sequence != 7 {
+		t.Fatalf("streamed = %v", eventIDs(streamed))
+	}
+
+	// 중간에 멈추면 다음 페이지를 읽지 않음
+	counting := &countingEventStore{EventStore: store}
+	taken := 0
+	for _, err := range StreamEvents(counting, "game-1", EventQuery{Limit: 3}) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		taken++
+		if taken == 2 {
+			break
+		}
+	}
+	if taken != 2 || counting.queries != 1 {
+		t.Fatalf("taken = %d, queries = %d, want 2 events from a single page", taken, counting.queries)
+	}
+
+	// 조회 에러는 그대로 전달하고 종료
+	yields := 0
+	for _, err := range StreamEvents(store, "game-1", EventQuery{Cursor: "not a cursor!"}) {
+		yields++
+		if !errors.Is(err, ErrInvalidCursor) {
+			t.Fatalf("err = %v, want ErrInvalidCursor", err)
+		}
+	}
+	if yields != 1 {
+		t.Fatalf("stream yielded %d times, want a single error", yields)
+	}
+}
+
+// countingEventStore QueryByGame 호출 수를 세는 저장소
+type countingEventStore struct {
+	EventStore
+	queries int
+}
+
+func (s *countingEventStore) QueryByGame(gameID string, query EventQuery) (*EventPage, error) {
+	s.queries++
+	return s.EventStore.QueryByGame(gameID, query)
+}
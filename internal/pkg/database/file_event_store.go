@@ -0,0 +1,149 @@
+package database
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"game-server/internal/domain"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// FileEventStore 게임별 append-only JSON Lines 파일에 이벤트를 저장하는 로컬 저장소
+// AWS 없이 개발/CI 환경에서 이벤트 기록 경로 전체를 사용할 수 있음
+type FileEventStore struct {
+	dir string
+	mu  sync.RWMutex
+}
+
+func NewFileEventStore(dir string) (*FileEventStore, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create event store directory: %w", err)
+	}
+	return &FileEventStore{dir: dir}, nil
+}
+
+func (s *FileEventStore) Append(events []domain.GameEvent) error {
+	byGame := make(map[string][]domain.GameEvent)
+	var order []string
+	for _, event := range events {
+		if _, exists := byGame[event.GameID]; !exists {
+			order = append(order, event.GameID)
+		}
+		byGame[event.GameID] = append(byGame[event.GameID], event)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, gameID := range order {
+		if err := s.appendToFile(gameID, byGame[gameID]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *FileEventStore) appendToFile(gameID string, events []domain.GameEvent) error {
+	path, err := s.pathFor(gameID)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open event file: %w", err)
+	}
+	defer file.Close()
+
+	writer := bufio.NewWriter(file)
+	for _, event := range events {
+		line, err := json.Marshal(event)
+		if err != nil {
+			return fmt.Errorf("failed to marshal event: %w", err)
+		}
+		writer.Write(line)
+		writer.WriteByte('\n')
+	}
+	if err := writer.Flush(); err != nil {
+		return fmt.Errorf("failed to write event file: %w", err)
+	}
+	return nil
+}
+
+func (s *FileEventStore) QueryByGame(gameID string, query EventQuery) (*EventPage, error) {
+	cursorID, err := decodeEventCursor(query.Cursor)
+	if err != nil {
+		return nil, err
+	}
+	limit := normalizePageSize(query.Limit)
+
+	path, err := s.pathFor(gameID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return &EventPage{Events: []domain.GameEvent{}}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open event file: %w", err)
+	}
+	defer file.Close()
+
+	// 파일은 eventId 오름차순이므로 조건에 맞는 이벤트를 모은 뒤 방향에 맞게 자름
+	var matched []domain.GameEvent
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 4*1024*1024)
+	for scanner.Scan() {
+		var event domain.GameEvent
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			return nil, fmt.Errorf("failed to parse event file: %w", err)
+		}
+		if cursorID != "" {
+			if !query.Descending && event.EventID <= cursorID {
+				continue
+			}
+			if query.Descending && event.EventID >= cursorID {
+				continue
+			}
+		}
+		if query.matches(&event) {
+			matched = append(matched, event)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read event file: %w", err)
+	}
+
+	if query.Descending {
+		slices.Reverse(matched)
+	}
+
+	page := &EventPage{Events: []domain.GameEvent{}}
+	if len(matched) > limit {
+		matched = matched[:limit]
+		page.NextCursor = encodeEventCursor(matched[limit-1].EventID)
+	}
+	page.Events = append(page.Events, matched...)
+
+	return page, nil
+}
+
+func (s *FileEventStore) Close() error {
+	return nil
+}
+
+func (s *FileEventStore) pathFor(gameID string) (string, error) {
+	if gameID == "" || strings.ContainsAny(gameID, `/\`) || gameID == "." || gameID == ".." {
+		return "", fmt.Errorf("invalid game id: %q", gameID)
+	}
+	return filepath.Join(s.dir, gameID+".jsonl"), nil
+}
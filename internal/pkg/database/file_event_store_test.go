@@ -0,0 +1,178 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+	"game-server/internal/domain"
+	"testing"
+	"time"
+)
+
+func newTestFileEventStore(t *testing.T) *FileEventStore {
+	t.Helper()
+	store, err := NewFileEventStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return store
+}
+
+func testEvent(gameID string, sequence int64, eventType string) domain.GameEvent {
+	return domain.GameEvent{
+		GameID:    gameID,
+		EventID:   fmt.Sprintf("%010d", sequence),
+		Sequence:  sequence,
+		Type:      eventType,
+		Timestamp: 1_700_000_000_000 + sequence*1000,
+	}
+}
+
+func eventIDs(events []domain.GameEvent) []string {
+	ids := make([]string, 0, len(events))
+	for _, event := range events {
+		ids = append(ids, event.EventID)
+	}
+	return ids
+}
+
+func TestFileEventStoreAppendThenQuery(t *testing.T) {
+	store := newTestFileEventStore(t)
+
+	// 두 게임의 이벤트가 섞여서 여러 번에 나눠 들어와도 게임별로 순서대로 저장
+	if err := store.Append([]domain.GameEvent{
+		testEvent("game-1", 1, "game_started"),
+		testEvent("game-2", 1, "game_started"),
+		testEvent("game-1", 2, "move"),
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Append([]domain.GameEvent{
+		testEvent("game-1", 3, "chat"),
+		testEvent("game-2", 2, "move"),
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	page, err := store.QueryByGame("game-1", EventQuery{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := fmt.Sprint(eventIDs(page.Events)); got != "[0000000001 0000000002 0000000003]" {
+		t.Fatalf("game-1 events = %s", got)
+	}
+	for _, event := range page.Events {
+		if event.GameID != "game-1" {
+			t.Fatalf("event from another game: %+v", event)
+		}
+	}
+	if page.NextCursor != "" {
+		t.Fatalf("single page should have no cursor, got %q", page.NextCursor)
+	}
+
+	page, err = store.QueryByGame("game-2", EventQuery{Descending: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := fmt.Sprint(eventIDs(page.Events)); got != "[0000000002 0000000001]" {
+		t.Fatalf("game-2 descending events = %s", got)
+	}
+
+	// 이벤트가 없는 게임은 빈 페이지
+	page, err = store.QueryByGame("game-3", EventQuery{})
+	if err != nil || len(page.Events) != 0 || page.Events == nil {
+		t.Fatalf("unknown game = (%+v, %v), want empty page", page, err)
+	}
+}
+
+func TestFileEventStoreRejectsInvalidGameID(t *testing.T) {
+	store := newTestFileEventStore(t)
+	for _, gameID := range []string{"", ".", "..", "../escape", `a\b`} {
+		if _, err := store.QueryByGame(gameID, EventQuery{}); err == nil {
+			t.Errorf("game id %q should be rejected", gameID)
+		}
+		if err := store.Append([]domain.GameEvent{{GameID: gameID, EventID: "1"}}); err == nil {
+			t.Errorf("append with game id %q should be rejected", gameID)
+		}
+	}
+}
+
+func TestFileEventStoreFilters(t *testing.T) {
+	store := newTestFileEventStore(t)
+	var events []domain.GameEvent
+	for sequence := int64(1); sequence <= 6; sequence++ {
+		eventType := "move"
+		if sequence%3 == 0 {
+			eventType = "chat"
+		}
+		events = append(events, testEvent("game-1", sequence, eventType))
+	}
+	events = append(events, testEvent("game-2", 1, "chat"))
+	if err := store.Append(events); err != nil {
+		t.Fatal(err)
+	}
+
+	page, err := store.QueryByGame("game-1", EventQuery{Types: []string{"chat"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := fmt.Sprint(eventIDs(page.Events)); got != "[0000000003 0000000006]" {
+		t.Fatalf("chat events = %s", got)
+	}
+
+	// 서버 timestamp 범위 (양쪽 포함)
+	page, err = store.QueryByGame("game-1", EventQuery{
+		From: time.UnixMilli(events[1].Timestamp),
+		To:   time.UnixMilli(events[3].Timestamp),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := fmt.Sprint(eventIDs(page.Events)); got != "[0000000002 0000000003 0000000004]" {
+		t.Fatalf("time range events = %s", got)
+	}
+}
+
+func TestFileEventStoreCursorPagination(t *testing.T) {
+	store := newTestFileEventStore(t)
+	var events []domain.GameEvent
+	for sequence := int64(1); sequence <= 5; sequence++ {
+		events = append(events, testEvent("game-1", sequence, "move"))
+	}
+	if err := store.Append(events); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []struct {
+		descending bool
+		want       string
+	}{
+		{false, "[0000000001 0000000002 0000000003 0000000004 0000000005]"},
+		{true, "[0000000005 0000000004 0000000003 0000000002 0000000001]"},
+	} {
+		query := EventQuery{Limit: 2, Descending: tc.descending}
+		var collected []domain.GameEvent
+		pages := 0
+		for {
+			page, err := store.QueryByGame("game-1", query)
+			if err != nil {
+				t.Fatal(err)
+			}
+			pages++
+			collected = append(collected, page.Events...)
+			if page.NextCursor == "" {
+				break
+			}
+			query.Cursor = page.NextCursor
+		}
+		if got := fmt.Sprint(eventIDs(collected)); got != tc.want {
+			t.Fatalf("descending=%v: events = %s, want %s", tc.descending, got, tc.want)
+		}
+		if pages != 3 {
+			t.Fatalf("descending=%v: pages = %d, want 3", tc.descending, pages)
+		}
+	}
+
+	if _, err := store.QueryByGame("game-1", EventQuery{Cursor: "not a cursor!"}); !errors.Is(err, ErrInvalidCursor) {
+		t.Fatalf("invalid cursor err = %v", err)
+	}
+}
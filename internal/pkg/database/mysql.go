@@ -1,11 +1,13 @@
 package database
 
 import (
+	"errors"
 	"fmt"
 	"game-server/internal/config"
 	"game-server/internal/domain"
 	"log"
 
+	mysqldriver "github.com/go-sql-driver/mysql"
 	"gorm.io/driver/mysql"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
@@ -70,9 +72,24 @@ func autoMigrateMySQL(db *gorm.DB) error {
 	return db.AutoMigrate(
 		&domain.Game{},
 		&domain.Match{},
+		&domain.Friendship{},
+		&domain.UserBlock{},
+		&domain.FriendSettings{},
 	)
 }
 
+// mysqlDuplicateEntry 유니크 인덱스 위반 에러 번호
+const mysqlDuplicateEntry = 1062
+
+// IsDuplicateKey 유니크 인덱스 위반 에러인지 확인 (동시에 같은 행을 만들려고 한 경우)
+func IsDuplicateKey(err error) bool {
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return true
+	}
+	var mysqlErr *mysqldriver.MySQLError
+	return errors.As(err, &mysqlErr) && mysqlErr.Number == mysqlDuplicateEntry
+}
+
 // GetWriterDB MySQL Writer 인스턴스 반환 (쓰기 작업용)
 func GetWriterDB() *gorm.DB {
 	return writerDB
@@ -0,0 +1,26 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	mysqldriver "github.com/go-sql-driver/mysql"
+	"gorm.io/gorm"
+)
+
+func TestIsDuplicateKey(t *testing.T) {
+	duplicate := &mysqldriver.MySQLError{Number: 1062, Message: "Duplicate entry 'a-b' for key 'idx_friendship_users'"}
+	if !IsDuplicateKey(duplicate) {
+		t.Fatal("MySQL 1062 should be a duplicate key")
+	}
+	if !IsDuplicateKey(fmt.Errorf("create friendship: %w", duplicate)) {
+		t.Fatal("wrapped MySQL 1062 should be a duplicate key")
+	}
+	if !IsDuplicateKey(gorm.ErrDuplicatedKey) {
+		t.Fatal("gorm.ErrDuplicatedKey should be a duplicate key")
+	}
+	if IsDuplicateKey(&mysqldriver.MySQLError{Number: 1213, Message: "Deadlock found"}) || IsDuplicateKey(errors.New("boom")) {
+		t.Fatal("other errors are not duplicate keys")
+	}
+}
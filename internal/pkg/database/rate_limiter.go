@@ -0,0 +1,50 @@
+package database
+
+import (
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// tokenBucketScript 여러 서버 노드가 같은 버킷을 공유하는 토큰 버킷
+// 시간은 Redis 서버 시각을 사용해서 노드 간 시계 차이의 영향을 받지 않음
+// 반환값: {허용 여부(1/0), 다음 토큰까지 남은 시간(ms)}
+var tokenBucketScript = redis.NewScript(`
+local rate = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+local time = redis.call('TIME')
+local now = tonumber(time[1]) + tonumber(time[2]) / 1000000
+
+local state = redis.call('HMGET', KEYS[1], 'tokens', 'ts')
+local tokens = tonumber(state[1])
+local ts = tonumber(state[2])
+if tokens == nil or ts == nil then
+	tokens = burst
+	ts = now
+end
+
+tokens = math.min(burst, tokens + math.max(0, now - ts) * rate)
+
+local allowed = 0
+local retry_ms = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+	allowed = 1
+else
+	retry_ms = math.ceil((1 - tokens) / rate * 1000)
+end
+
+redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'ts', tostring(now))
+redis.call('PEXPIRE', KEYS[1], math.ceil(burst / rate * 1000) + 1000)
+return {allowed, retry_ms}
+`)
+
+// TakeToken 토큰 버킷에서 토큰 하나 사용 (초당 rate개 충전, 최대 burst개)
+// 토큰이 없으면 false와 다음 토큰이 생길 때까지의 시간 반환
+func TakeToken(key string, rate float64, burst int) (bool, time.Duration, error) {
+	result, err := tokenBucketScript.Run(ctx, redisClient, []string{key}, rate, burst).Int64Slice()
+	if err != nil {
+		return false, 0, err
+	}
+	return result[0] == 1, time.Duration(result[1]) * time.Millisecond, nil
+}
@@ -0,0 +1,53 @@
+package database_test
+
+import (
+	"game-server/internal/pkg/database"
+	"game-server/internal/pkg/database/redistest"
+	"testing"
+	"time"
+)
+
+func TestTakeToken(t *testing.T) {
+	server := redistest.Start(t)
+	now := time.Unix(1_700_000_000, 0)
+	server.SetTime(now)
+
+	for i := 0; i < 3; i++ {
+		ok, _, err := database.TakeToken("ratelimit:test", 2, 3)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !ok {
+			t.Fatalf("token %d should be allowed within burst", i+1)
+		}
+	}
+
+	ok, retryAfter, err := database.TakeToken("ratelimit:test", 2, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ok {
+		t.Fatal("token beyond burst should be rejected")
+	}
+	if retryAfter != 500*time.Millisecond {
+		t.Fatalf("retryAfter = %s, want 500ms", retryAfter)
+	}
+
+	// 초당 2개 충전이므로 0.5초 뒤에는 다시 하나 허용
+	server.SetTime(now.Add(500 * time.Millisecond))
+	if ok, _, _ := database.TakeToken("ratelimit:test", 2, 3); !ok {
+		t.Fatal("token should be refilled after 500ms")
+	}
+	if ok, _, _ := database.TakeToken("ratelimit:test", 2, 3); ok {
+		t.Fatal("only one token should have been refilled")
+	}
+
+	// 다른 키는 별도 버킷
+	if ok, _, _ := database.TakeToken("ratelimit:other", 2, 3); !ok {
+		t.Fatal("buckets must be independent per key")
+	}
+
+	if ttl := server.TTL("ratelimit:test"); ttl <= 0 {
+		t.Fatalf("bucket key should expire, ttl = %s", ttl)
+	}
+}
@@ -87,6 +87,20 @@ func Incr(key string) (int64, error) {
 	return redisClient.Incr(ctx, key).Result()
 }
 
+// IncrWithExpire 카운터를 증가시키고 만료 시간을 다시 설정 (한 트랜잭션으로 처리)
+func IncrWithExpire(key string, expiration time.Duration) (int64, error) {
+	var incr *redis.IntCmd
+	_, err := redisClient.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		incr = pipe.Incr(ctx, key)
+		pipe.Expire(ctx, key, expiration)
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return incr.Val(), nil
+}
+
 // Decr 카운터 감소
 func Decr(key string) (int64, error) {
 	return redisClient.Decr(ctx, key).Result()
@@ -97,6 +111,19 @@ func SAdd(key string, member string) error {
 	return redisClient.SAdd(ctx, key, member).Err()
 }
 
+// ReplaceSet Set 전체를 주어진 멤버로 교체하고 만료 시간 설정 (한 트랜잭션으로 처리)
+func ReplaceSet(key string, members []string, expiration time.Duration) error {
+	_, err := redisClient.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Del(ctx, key)
+		for _, member := range members {
+			pipe.SAdd(ctx, key, member)
+		}
+		pipe.Expire(ctx, key, expiration)
+		return nil
+	})
+	return err
+}
+
 // SRem Set에서 멤버 제거
 func SRem(key string, member string) error {
 	return redisClient.SRem(ctx, key, member).Err()
@@ -126,3 +153,33 @@ func SMembers(key string) ([]string, error) {
 func SIsMember(key string, member interface{}) (bool, error) {
 	return redisClient.SIsMember(ctx, key, member).Result()
 }
+
+// SMIsMember 여러 멤버가 Set에 있는지 한 번에 확인 (멤버 순서대로 결과 반환)
+func SMIsMember(key string, members ...interface{}) ([]bool, error) {
+	return redisClient.SMIsMember(ctx, key, members...).Result()
+}
+
+// Expire 키 만료 시간 설정
+func Expire(key string, expiration time.Duration) error {
+	return redisClient.Expire(ctx, key, expiration).Err()
+}
+
+// Publish 채널에 메시지 발행
+func Publish(channel string, message string) error {
+	return redisClient.Publish(ctx, channel, message).Err()
+}
+
+// Subscribe 채널 구독
+func Subscribe(channels ...string) *redis.PubSub {
+	return redisClient.Subscribe(ctx, channels...)
+}
+
+// SetNX 키가 없을 때만 값 저장 (저장했으면 true)
+func SetNX(key string, value string, expiration time.Duration) (bool, error) {
+	return redisClient.SetNX(ctx, key, value, expiration).Result()
+}
+
+// RunScript Lua 스크립트 실행 (여러 명령을 원자적으로 처리할 때 사용)
+func RunScript(script *redis.Script, keys []string, args ...interface{}) *redis.Cmd {
+	return script.Run(ctx, redisClient, keys, args...)
+}
@@ -0,0 +1,23 @@
+// Package redistest 테스트에서 database 패키지가 인메모리 Redis(miniredis)를 사용하도록 설정
+package redistest
+
+import (
+	"game-server/internal/config"
+	"game-server/internal/pkg/database"
+	"net"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+)
+
+// Start miniredis를 띄우고 database 패키지의 Redis 클라이언트를 연결 (테스트가 끝나면 정리)
+func Start(t testing.TB) *miniredis.Miniredis {
+	t.Helper()
+	server := miniredis.RunT(t)
+	host, port, _ := net.SplitHostPort(server.Addr())
+	if err := database.InitRedis(&config.Config{Redis: config.RedisConfig{Host: host, Port: port}}); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { database.CloseRedis() })
+	return server
+}
@@ -1,9 +1,12 @@
 package errors
 
+import "time"
+
 type AppError struct {
-	Code       string `json:"code"`
-	Message    string `json:"message"`
-	StatusCode int    `json:"-"`
+	Code       string                 `json:"code"`
+	Message    string                 `json:"message"`
+	Details    map[string]interface{} `json:"details,omitempty"`
+	StatusCode int                    `json:"-"`
 }
 
 func (e *AppError) Error() string {
@@ -65,3 +68,328 @@ func DBError() *AppError {
 		StatusCode: 500,
 	}
 }
+
+func ReplayNotFound() *AppError {
+	return &AppError{
+		Code:       "REPLAY_NOT_FOUND",
+		Message:    "리플레이할 게임 기록을 찾을 수 없습니다",
+		StatusCode: 404,
+	}
+}
+
+func TokenRevoked() *AppError {
+	return &AppError{
+		Code:       "TOKEN_REVOKED",
+		Message:    "폐기된 토큰입니다",
+		StatusCode: 401,
+	}
+}
+
+func UserBanned(reason string) *AppError {
+	return &AppError{
+		Code:       "USER_BANNED",
+		Message:    "이용이 제한된 계정입니다: " + reason,
+		StatusCode: 403,
+	}
+}
+
+func Forbidden() *AppError {
+	return &AppError{
+		Code:       "FORBIDDEN",
+		Message:    "요청에 대한 권한이 없습니다",
+		StatusCode: 403,
+	}
+}
+
+// ========== 소켓 프로토콜 에러 ==========
+
+func AuthRequired() *AppError {
+	return &AppError{
+		Code:       "AUTH_REQUIRED",
+		Message:    "인증이 필요합니다",
+		StatusCode: 401,
+	}
+}
+
+func TokenRequired() *AppError {
+	return &AppError{
+		Code:       "TOKEN_REQUIRED",
+		Message:    "토큰이 필요합니다",
+		StatusCode: 401,
+	}
+}
+
+func TokenExpired() *AppError {
+	return &AppError{
+		Code:       "TOKEN_EXPIRED",
+		Message:    "토큰이 만료되었습니다",
+		StatusCode: 401,
+	}
+}
+
+func TokenUserMismatch() *AppError {
+	return &AppError{
+		Code:       "TOKEN_USER_MISMATCH",
+		Message:    "다른 사용자의 토큰입니다",
+		StatusCode: 403,
+	}
+}
+
+func UpgradeRequired(clientVersion, minVersion, maxVersion int) *AppError {
+	return &AppError{
+		Code:    "UPGRADE_REQUIRED",
+		Message: "지원하지 않는 클라이언트 버전입니다. 게임을 업데이트해주세요",
+		Details: map[string]interface{}{
+			"clientVersion": clientVersion,
+			"minVersion":    minVersion,
+			"maxVersion":    maxVersion,
+		},
+		StatusCode: 426,
+	}
+}
+
+func RateLimited(retryAfter time.Duration) *AppError {
+	return &AppError{
+		Code:    "RATE_LIMITED",
+		Message: "요청이 너무 많습니다. 잠시 후 다시 시도해주세요",
+		Details: map[string]interface{}{
+			"retryAfterMs": retryAfter.Milliseconds(),
+		},
+		StatusCode: 429,
+	}
+}
+
+func ServerShuttingDown() *AppError {
+	return &AppError{
+		Code:       "SERVER_SHUTTING_DOWN",
+		Message:    "서버가 종료 중입니다. 잠시 후 다시 접속해주세요",
+		StatusCode: 503,
+	}
+}
+
+func ServerBusy() *AppError {
+	return &AppError{
+		Code:       "SERVER_BUSY",
+		Message:    "접속자가 많아 연결할 수 없습니다. 잠시 후 다시 시도해주세요",
+		StatusCode: 503,
+	}
+}
+
+func TooManyConnections() *AppError {
+	return &AppError{
+		Code:       "TOO_MANY_CONNECTIONS",
+		Message:    "같은 주소에서 너무 많은 연결이 열려 있습니다",
+		StatusCode: 429,
+	}
+}
+
+func AuthTimeout() *AppError {
+	return &AppError{
+		Code:       "AUTH_TIMEOUT",
+		Message:    "제한 시간 안에 인증하지 않아 연결을 종료합니다",
+		StatusCode: 401,
+	}
+}
+
+func SessionAlreadyActive() *AppError {
+	return &AppError{
+		Code:       "SESSION_ALREADY_ACTIVE",
+		Message:    "이미 다른 곳에서 접속 중입니다",
+		StatusCode: 409,
+	}
+}
+
+func DeviceCapabilityRequired(capability string) *AppError {
+	return &AppError{
+		Code:    "DEVICE_CAPABILITY_REQUIRED",
+		Message: "이 기기에서는 사용할 수 없는 기능입니다",
+		Details: map[string]interface{}{
+			"capability": capability,
+		},
+		StatusCode: 403,
+	}
+}
+
+func InternalError() *AppError {
+	return &AppError{
+		Code:       "INTERNAL_ERROR",
+		Message:    "요청을 처리하는 중 오류가 발생해 연결을 종료합니다",
+		StatusCode: 500,
+	}
+}
+
+func UnknownMessageType() *AppError {
+	return &AppError{
+		Code:       "UNKNOWN_MESSAGE_TYPE",
+		Message:    "알 수 없는 메시지 타입입니다",
+		StatusCode: 400,
+	}
+}
+
+// ========== 매치 에러 ==========
+
+func MatchNotFound() *AppError {
+	return &AppError{
+		Code:       "MATCH_NOT_FOUND",
+		Message:    "매치를 찾을 수 없습니다",
+		StatusCode: 404,
+	}
+}
+
+func MatchFull() *AppError {
+	return &AppError{
+		Code:       "MATCH_FULL",
+		Message:    "매치 인원이 가득 찼습니다",
+		StatusCode: 409,
+	}
+}
+
+func NotHost() *AppError {
+	return &AppError{
+		Code:       "NOT_HOST",
+		Message:    "호스트만 할 수 있는 요청입니다",
+		StatusCode: 403,
+	}
+}
+
+func NotInMatch() *AppError {
+	return &AppError{
+		Code:       "NOT_IN_MATCH",
+		Message:    "참가 중인 매치가 없습니다",
+		StatusCode: 409,
+	}
+}
+
+func NotEnoughPlayers() *AppError {
+	return &AppError{
+		Code:       "NOT_ENOUGH_PLAYERS",
+		Message:    "매치를 시작하려면 2명 이상의 플레이어가 필요합니다",
+		StatusCode: 409,
+	}
+}
+
+func InviteExpired() *AppError {
+	return &AppError{
+		Code:       "INVITE_EXPIRED",
+		Message:    "초대가 없거나 만료되었습니다",
+		StatusCode: 410,
+	}
+}
+
+// ========== 친구 에러 ==========
+
+func CannotFriendSelf() *AppError {
+	return &AppError{
+		Code:       "CANNOT_FRIEND_SELF",
+		Message:    "자기 자신에게는 친구 요청을 보낼 수 없습니다",
+		StatusCode: 400,
+	}
+}
+
+func AlreadyFriends() *AppError {
+	return &AppError{
+		Code:       "ALREADY_FRIENDS",
+		Message:    "이미 친구입니다",
+		StatusCode: 409,
+	}
+}
+
+func FriendRequestAlreadySent() *AppError {
+	return &AppError{
+		Code:       "FRIEND_REQUEST_ALREADY_SENT",
+		Message:    "이미 친구 요청을 보냈습니다",
+		StatusCode: 409,
+	}
+}
+
+func FriendRequestNotFound() *AppError {
+	return &AppError{
+		Code:       "FRIEND_REQUEST_NOT_FOUND",
+		Message:    "친구 요청을 찾을 수 없습니다",
+		StatusCode: 404,
+	}
+}
+
+func NotFriends() *AppError {
+	return &AppError{
+		Code:       "NOT_FRIENDS",
+		Message:    "친구가 아닙니다",
+		StatusCode: 404,
+	}
+}
+
+func UserBlocked() *AppError {
+	return &AppError{
+		Code:       "USER_BLOCKED",
+		Message:    "차단했거나 차단된 사용자입니다",
+		StatusCode: 403,
+	}
+}
+
+// ========== 게임 에러 ==========
+
+func GameNotFound() *AppError {
+	return &AppError{
+		Code:       "GAME_NOT_FOUND",
+		Message:    "게임을 찾을 수 없습니다",
+		StatusCode: 404,
+	}
+}
+
+func NotInGame() *AppError {
+	return &AppError{
+		Code:       "NOT_IN_GAME",
+		Message:    "참가 중인 게임이 없습니다",
+		StatusCode: 409,
+	}
+}
+
+func GameNotInProgress() *AppError {
+	return &AppError{
+		Code:       "GAME_NOT_IN_PROGRESS",
+		Message:    "진행 중인 게임이 아닙니다",
+		StatusCode: 409,
+	}
+}
+
+func GameAlreadyStarted() *AppError {
+	return &AppError{
+		Code:       "GAME_ALREADY_STARTED",
+		Message:    "이미 시작된 게임입니다",
+		StatusCode: 409,
+	}
+}
+
+func GameAlreadyEnded() *AppError {
+	return &AppError{
+		Code:       "GAME_ALREADY_ENDED",
+		Message:    "이미 종료된 게임입니다",
+		StatusCode: 409,
+	}
+}
+
+// ========== 리플레이 에러 ==========
+
+func UnsupportedReplaySpeed() *AppError {
+	return &AppError{
+		Code:       "UNSUPPORTED_REPLAY_SPEED",
+		Message:    "지원하지 않는 리플레이 배속입니다",
+		StatusCode: 400,
+	}
+}
+
+func ReplayNotActive() *AppError {
+	return &AppError{
+		Code:       "REPLAY_NOT_ACTIVE",
+		Message:    "진행 중인 리플레이가 없습니다",
+		StatusCode: 409,
+	}
+}
+
+func ReplayBusy() *AppError {
+	return &AppError{
+		Code:       "REPLAY_BUSY",
+		Message:    "리플레이 제어 요청이 너무 많습니다",
+		StatusCode: 429,
+	}
+}
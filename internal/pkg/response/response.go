@@ -25,10 +25,14 @@ func Success(context *gin.Context, data interface{}) {
 
 func Error(context *gin.Context, err error) {
 	if appErr, ok := err.(*errors.AppError); ok {
-		context.JSON(appErr.StatusCode, gin.H{
+		body := gin.H{
 			"code":    appErr.Code,
 			"message": appErr.Message,
-		})
+		}
+		if appErr.Details != nil {
+			body["details"] = appErr.Details
+		}
+		context.JSON(appErr.StatusCode, body)
 		return
 	}
 
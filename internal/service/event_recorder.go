@@ -0,0 +1,144 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"game-server/internal/domain"
+	"game-server/internal/pkg/database"
+	"log"
+	"sync"
+	"time"
+)
+
+const (
+	EVENT_BATCH_SIZE      = 25
+	EVENT_BUFFER_SIZE     = 10000
+	EVENT_FLUSH_INTERVAL  = 500 * time.Millisecond
+	EVENT_SEQUENCE_EXPIRE = 24 * time.Hour
+)
+
+// EventRecorder 게임 이벤트를 버퍼에 모았다가 이벤트 저장소에 배치로 기록
+// 저장소가 느려도 소켓 핸들러는 버퍼에 넣고 바로 반환됨
+type EventRecorder struct {
+	events chan domain.GameEvent
+	done   chan struct{}
+
+	mu     sync.RWMutex // events 전송과 Close 사이의 경합 방지
+	closed bool
+}
+
+// ErrRecorderClosed 종료된 기록기에 이벤트를 기록하려 할 때 반환
+var ErrRecorderClosed = errors.New("event recorder is closed")
+
+func NewEventRecorder() *EventRecorder {
+	recorder := &EventRecorder{
+		events: make(chan domain.GameEvent, EVENT_BUFFER_SIZE),
+		done:   make(chan struct{}),
+	}
+	go recorder.run()
+	return recorder
+}
+
+// Record 게임 이벤트에 순번과 서버 시각을 붙여서 버퍼에 추가
+func (r *EventRecorder) Record(gameID, userID, eventType string, data interface{}) error {
+	if r.isClosed() {
+		return ErrRecorderClosed
+	}
+
+	sequence, err := r.nextSequence(gameID)
+	if err != nil {
+		return fmt.Errorf("failed to assign event sequence: %w", err)
+	}
+
+	// 저장소에 상관없이 같은 형태로 남도록 JSON 호환 값으로 정규화
+	var normalized interface{}
+	if data != nil {
+		if err := decodeEventData(data, &normalized); err != nil {
+			return fmt.Errorf("failed to normalize event data: %w", err)
+		}
+	}
+
+	event := domain.GameEvent{
+		GameID:    gameID,
+		EventID:   fmt.Sprintf("%012d", sequence),
+		Sequence:  sequence,
+		Type:      eventType,
+		UserID:    userID,
+		Data:      normalized,
+		Timestamp: time.Now().UnixMilli(),
+	}
+
+	// 종료 중에도 닫힌 채널로 보내지 않도록 잠금 안에서 확인 후 전송
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if r.closed {
+		return ErrRecorderClosed
+	}
+
+	select {
+	case r.events <- event:
+		return nil
+	default:
+		return fmt.Errorf("event buffer is full, dropped %s event for game %s", eventType, gameID)
+	}
+}
+
+// Close 남은 이벤트를 모두 기록하고 종료 (이후 Record는 ErrRecorderClosed 반환)
+func (r *EventRecorder) Close() {
+	r.mu.Lock()
+	if !r.closed {
+		r.closed = true
+		close(r.events)
+	}
+	r.mu.Unlock()
+	<-r.done
+}
+
+func (r *EventRecorder) isClosed() bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.closed
+}
+
+// nextSequence 게임별로 단조 증가하는 이벤트 순번 발급
+// 발급할 때마다 만료 시간을 갱신해서 오래 진행되는 게임도 순번이 1부터 다시 시작하지 않음
+func (r *EventRecorder) nextSequence(gameID string) (int64, error) {
+	return database.IncrWithExpire(fmt.Sprintf("game:events:seq:%s", gameID), EVENT_SEQUENCE_EXPIRE)
+}
+
+func (r *EventRecorder) run() {
+	defer close(r.done)
+
+	ticker := time.NewTicker(EVENT_FLUSH_INTERVAL)
+	defer ticker.Stop()
+
+	batch := make([]domain.GameEvent, 0, EVENT_BATCH_SIZE)
+	for {
+		select {
+		case event, ok := <-r.events:
+			if !ok {
+				r.flush(batch)
+				return
+			}
+			batch = append(batch, event)
+			if len(batch) >= EVENT_BATCH_SIZE {
+				r.flush(batch)
+				batch = batch[:0]
+			}
+		case <-ticker.C:
+			if len(batch) > 0 {
+				r.flush(batch)
+				batch = batch[:0]
+			}
+		}
+	}
+}
+
+func (r *EventRecorder) flush(batch []domain.GameEvent) {
+	if len(batch) == 0 {
+		return
+	}
+	if err := database.GetEventStore().Append(batch); err != nil {
+		log.Printf("Failed to record %d game events: %v", len(batch), err)
+	}
+}
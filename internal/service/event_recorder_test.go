@@ -0,0 +1,17 @@
+package service
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestRecordAfterCloseReturnsError(t *testing.T) {
+	recorder := NewEventRecorder()
+	recorder.Close()
+	recorder.Close() // 여러 번 호출해도 안전
+
+	err := recorder.Record("game-1", "user-1", "move", nil)
+	if !errors.Is(err, ErrRecorderClosed) {
+		t.Fatalf("Record after Close = %v, want ErrRecorderClosed", err)
+	}
+}
@@ -0,0 +1,483 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"game-server/internal/domain"
+	"game-server/internal/dto"
+	"game-server/internal/pkg/database"
+	"game-server/internal/pkg/errors"
+	"log"
+	"slices"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	// FRIEND_CHANNEL 친구 요청/수락/삭제 알림을 모든 서버 노드에 전달하는 Redis 채널
+	FRIEND_CHANNEL = "friends:events"
+
+	// BLOCK_CACHE_TTL Redis에 캐시한 차단 목록 보관 시간
+	BLOCK_CACHE_TTL = time.Hour
+
+	// blockCacheMarker 차단 목록이 비어 있어도 캐시된 상태임을 표시하는 멤버 (사용자 ID로 쓰이지 않는 값)
+	blockCacheMarker = "-"
+)
+
+// 친구 관계 상태
+const (
+	FRIENDSHIP_PENDING  = "pending"
+	FRIENDSHIP_ACCEPTED = "accepted"
+)
+
+// 매치 초대를 받을 수 있는 상대 (FriendSettings.AllowInvitesFrom)
+const (
+	INVITES_FROM_FRIENDS  = "friends"
+	INVITES_FROM_EVERYONE = "everyone"
+	INVITES_FROM_NOBODY   = "nobody"
+)
+
+// 친구 알림 타입 (소켓 메시지 타입으로 그대로 전달)
+const (
+	FRIEND_EVENT_REQUEST_RECEIVED = "friend_request_received"
+	FRIEND_EVENT_REQUEST_ACCEPTED = "friend_request_accepted"
+	FRIEND_EVENT_REMOVED          = "friend_removed"
+)
+
+// FriendService 친구 관계, 차단, 친구 설정 관리 (MySQL)
+type FriendService struct{}
+
+func NewFriendService() *FriendService {
+	return &FriendService{}
+}
+
+// SendRequest 친구 요청
+// 상대가 이미 나에게 요청을 보낸 상태면 바로 친구가 됨
+func (s *FriendService) SendRequest(userID, targetID string) (*dto.FriendRequestResponse, error) {
+	if targetID == "" {
+		return nil, errors.InvalidInput()
+	}
+	if userID == targetID {
+		return nil, errors.CannotFriendSelf()
+	}
+
+	blocked, err := s.IsBlockedEither(userID, targetID)
+	if err != nil {
+		return nil, errors.DBError()
+	}
+	if blocked {
+		return nil, errors.UserBlocked()
+	}
+
+	db := database.GetWriterDB()
+	existing, err := findFriendship(db, userID, targetID)
+	if err != nil {
+		return nil, errors.DBError()
+	}
+
+	if existing != nil {
+		return s.resolveExistingRequest(userID, targetID, existing)
+	}
+
+	friendship := &domain.Friendship{
+		RequesterID: userID,
+		AddresseeID: targetID,
+		Status:      FRIENDSHIP_PENDING,
+	}
+	if err := db.Create(friendship).Error; err != nil {
+		if !database.IsDuplicateKey(err) {
+			return nil, errors.DBError()
+		}
+		// 상대가 동시에 요청을 보내서 먼저 저장된 경우
+		existing, err := findFriendship(db, userID, targetID)
+		if err != nil || existing == nil {
+			return nil, errors.DBError()
+		}
+		return s.resolveExistingRequest(userID, targetID, existing)
+	}
+
+	s.publish(FRIEND_EVENT_REQUEST_RECEIVED, targetID, userID)
+	return &dto.FriendRequestResponse{UserID: targetID, Status: FRIENDSHIP_PENDING}, nil
+}
+
+// resolveExistingRequest 이미 관계가 있을 때의 친구 요청 처리 (상대가 보낸 요청이면 수락)
+func (s *FriendService) resolveExistingRequest(userID, targetID string, existing *domain.Friendship) (*dto.FriendRequestResponse, error) {
+	switch {
+	case existing.Status == FRIENDSHIP_ACCEPTED:
+		return nil, errors.AlreadyFriends()
+	case existing.RequesterID == userID:
+		return nil, errors.FriendRequestAlreadySent()
+	default:
+		if _, err := s.AcceptRequest(userID, targetID); err != nil {
+			return nil, err
+		}
+		return &dto.FriendRequestResponse{UserID: targetID, Status: FRIENDSHIP_ACCEPTED}, nil
+	}
+}
+
+// AcceptRequest 받은 친구 요청 수락
+func (s *FriendService) AcceptRequest(userID, requesterID string) (*dto.FriendInfo, error) {
+	now := time.Now()
+	result := database.GetWriterDB().Model(&domain.Friendship{}).
+		Where("requester_id = ? AND addressee_id = ? AND status = ?", requesterID, userID, FRIENDSHIP_PENDING).
+		Updates(map[string]interface{}{"status": FRIENDSHIP_ACCEPTED, "accepted_at": now})
+	if result.Error != nil {
+		return nil, errors.DBError()
+	}
+	if result.RowsAffected == 0 {
+		return nil, errors.FriendRequestNotFound()
+	}
+
+	s.publish(FRIEND_EVENT_REQUEST_ACCEPTED, requesterID, userID)
+	return &dto.FriendInfo{UserID: requesterID, Since: now}, nil
+}
+
+// DeclineRequest 받은 친구 요청 거절 (요청한 사용자에게는 알리지 않음)
+func (s *FriendService) DeclineRequest(userID, requesterID string) error {
+	result := database.GetWriterDB().
+		Where("requester_id = ? AND addressee_id = ? AND status = ?", requesterID, userID, FRIENDSHIP_PENDING).
+		Delete(&domain.Friendship{})
+	if result.Error != nil {
+		return errors.DBError()
+	}
+	if result.RowsAffected == 0 {
+		return errors.FriendRequestNotFound()
+	}
+	return nil
+}
+
+// RemoveFriend 친구 삭제 (보낸 요청 취소도 포함)
+func (s *FriendService) RemoveFriend(userID, friendID string) error {
+	result := deleteFriendship(database.GetWriterDB(), userID, friendID)
+	if result.Error != nil {
+		return errors.DBError()
+	}
+	if result.RowsAffected == 0 {
+		return errors.NotFriends()
+	}
+
+	s.publish(FRIEND_EVENT_REMOVED, friendID, userID)
+	return nil
+}
+
+// ListFriends 친구 목록과 대기 중인 요청
+func (s *FriendService) ListFriends(userID string) (*dto.FriendListResponse, error) {
+	var friendships []domain.Friendship
+	err := database.GetReaderDB().
+		Where("requester_id = ? OR addressee_id = ?", userID, userID).
+		Order("created_at").
+		Find(&friendships).Error
+	if err != nil {
+		return nil, errors.DBError()
+	}
+
+	response := &dto.FriendListResponse{
+		Friends:  []dto.FriendInfo{},
+		Requests: []dto.FriendRequestInfo{},
+	}
+	for _, friendship := range friendships {
+		otherID := friendship.AddresseeID
+		direction := "outgoing"
+		if friendship.AddresseeID == userID {
+			otherID = friendship.RequesterID
+			direction = "incoming"
+		}
+
+		if friendship.Status == FRIENDSHIP_ACCEPTED {
+			since := friendship.CreatedAt
+			if friendship.AcceptedAt != nil {
+				since = *friendship.AcceptedAt
+			}
+			response.Friends = append(response.Friends, dto.FriendInfo{UserID: otherID, Since: since})
+			continue
+		}
+		response.Requests = append(response.Requests, dto.FriendRequestInfo{
+			UserID:    otherID,
+			Direction: direction,
+			CreatedAt: friendship.CreatedAt,
+		})
+	}
+	return response, nil
+}
+
+// AreFriends 서로 친구인지 확인
+func (s *FriendService) AreFriends(userID, otherID string) (bool, error) {
+	var count int64
+	lowID, highID := domain.FriendshipPair(userID, otherID)
+	err := database.GetReaderDB().Model(&domain.Friendship{}).
+		Where("low_user_id = ? AND high_user_id = ? AND status = ?", lowID, highID, FRIENDSHIP_ACCEPTED).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// FilterFriends otherIDs 중 수락된 친구이면서 어느 쪽도 차단하지 않은 사용자만 반환 (otherIDs 순서 유지)
+func (s *FriendService) FilterFriends(userID string, otherIDs []string) ([]string, error) {
+	var friendships []domain.Friendship
+	err := database.GetReaderDB().
+		Where("((requester_id = ? AND addressee_id IN ?) OR (addressee_id = ? AND requester_id IN ?)) AND status = ?",
+			userID, otherIDs, userID, otherIDs, FRIENDSHIP_ACCEPTED).
+		Find(&friendships).Error
+	if err != nil {
+		return nil, err
+	}
+
+	friends := make(map[string]bool, len(friendships))
+	for _, friendship := range friendships {
+		if friendship.RequesterID == userID {
+			friends[friendship.AddresseeID] = true
+		} else {
+			friends[friendship.RequesterID] = true
+		}
+	}
+
+	var result []string
+	for _, otherID := range otherIDs {
+		if !friends[otherID] || slices.Contains(result, otherID) {
+			continue
+		}
+		blocked, err := s.IsBlockedEither(userID, otherID)
+		if err != nil {
+			return nil, err
+		}
+		if !blocked {
+			result = append(result, otherID)
+		}
+	}
+	return result, nil
+}
+
+// Block 사용자 차단 (친구 관계와 대기 중인 요청도 삭제)
+// 친구였다면 상대에게 friend_removed를 보내서 모든 노드에서 서로의 접속 상태 구독도 해제되게 함
+func (s *FriendService) Block(userID, targetID string) error {
+	if targetID == "" {
+		return errors.InvalidInput()
+	}
+	if userID == targetID {
+		return errors.BadRequestWithMessage("자기 자신은 차단할 수 없습니다")
+	}
+
+	var removed *domain.Friendship
+	err := database.GetWriterDB().Transaction(func(tx *gorm.DB) error {
+		var count int64
+		if err := tx.Model(&domain.UserBlock{}).Where("user_id = ? AND blocked_id = ?", userID, targetID).Count(&count).Error; err != nil {
+			return err
+		}
+		if count == 0 {
+			// 동시에 같은 차단 요청이 먼저 저장됐으면 이미 차단된 것으로 처리
+			if err := tx.Create(&domain.UserBlock{UserID: userID, BlockedID: targetID}).Error; err != nil && !database.IsDuplicateKey(err) {
+				return err
+			}
+		}
+
+		existing, err := findFriendship(tx, userID, targetID)
+		if err != nil || existing == nil {
+			return err
+		}
+		removed = existing
+		return deleteFriendship(tx, userID, targetID).Error
+	})
+	if err != nil {
+		return errors.DBError()
+	}
+
+	s.rebuildBlockCache(userID)
+	if removed != nil && removed.Status == FRIENDSHIP_ACCEPTED {
+		s.publish(FRIEND_EVENT_REMOVED, targetID, userID)
+	}
+	return nil
+}
+
+// Unblock 차단 해제
+func (s *FriendService) Unblock(userID, targetID string) error {
+	result := database.GetWriterDB().
+		Where("user_id = ? AND blocked_id = ?", userID, targetID).
+		Delete(&domain.UserBlock{})
+	if result.Error != nil {
+		return errors.DBError()
+	}
+	if result.RowsAffected == 0 {
+		return errors.NotFound()
+	}
+
+	s.rebuildBlockCache(userID)
+	return nil
+}
+
+// ListBlocks 내가 차단한 사용자 목록
+func (s *FriendService) ListBlocks(userID string) ([]dto.BlockInfo, error) {
+	var blocks []domain.UserBlock
+	if err := database.GetReaderDB().Where("user_id = ?", userID).Order("created_at").Find(&blocks).Error; err != nil {
+		return nil, errors.DBError()
+	}
+
+	result := make([]dto.BlockInfo, 0, len(blocks))
+	for _, block := range blocks {
+		result = append(result, dto.BlockInfo{UserID: block.BlockedID, BlockedAt: block.CreatedAt})
+	}
+	return result, nil
+}
+
+// IsBlockedEither 둘 중 한 명이라도 상대를 차단했는지 확인
+// 초대, 매치 참가, 채팅 전달 등 두 사용자를 함께 두기 전에 확인하는 기준 (소켓 경로에서 쓰이므로 Redis 캐시 사용)
+func (s *FriendService) IsBlockedEither(userID, otherID string) (bool, error) {
+	blocked, err := s.hasBlocked(userID, otherID)
+	if err != nil || blocked {
+		return blocked, err
+	}
+	return s.hasBlocked(otherID, userID)
+}
+
+// BlockedAmong 상대 목록 중 userID와 차단 관계인 사용자
+func (s *FriendService) BlockedAmong(userID string, otherIDs []string) (map[string]bool, error) {
+	blocked := make(map[string]bool)
+	for _, otherID := range otherIDs {
+		if otherID == userID {
+			continue
+		}
+		isBlocked, err := s.IsBlockedEither(userID, otherID)
+		if err != nil {
+			return nil, err
+		}
+		if isBlocked {
+			blocked[otherID] = true
+		}
+	}
+	return blocked, nil
+}
+
+// hasBlocked userID가 otherID를 차단했는지 확인 (캐시가 없으면 MySQL에서 읽어서 캐시)
+// Redis 장애 시에는 MySQL에서 직접 확인
+func (s *FriendService) hasBlocked(userID, otherID string) (bool, error) {
+	// 캐시 표시와 대상 사용자를 한 번에 확인
+	members, err := database.SMIsMember(blockCacheKey(userID), blockCacheMarker, otherID)
+	if err == nil && members[0] {
+		return members[1], nil
+	}
+
+	blockedIDs, err := s.loadBlockedIDs(userID)
+	if err != nil {
+		return false, err
+	}
+	if err := s.cacheBlockedIDs(userID, blockedIDs); err != nil {
+		log.Printf("Failed to cache block list for user %s: %v", userID, err)
+	}
+	return slices.Contains(blockedIDs, otherID), nil
+}
+
+// loadBlockedIDs MySQL에서 차단 목록 조회
+// 캐시는 BLOCK_CACHE_TTL 동안 유지되므로 복제 지연이 있는 리더 대신 라이터에서 읽음
+func (s *FriendService) loadBlockedIDs(userID string) ([]string, error) {
+	var blockedIDs []string
+	err := database.GetWriterDB().Model(&domain.UserBlock{}).
+		Where("user_id = ?", userID).
+		Pluck("blocked_id", &blockedIDs).Error
+	return blockedIDs, err
+}
+
+func (s *FriendService) cacheBlockedIDs(userID string, blockedIDs []string) error {
+	return database.ReplaceSet(blockCacheKey(userID), append(blockedIDs, blockCacheMarker), BLOCK_CACHE_TTL)
+}
+
+// rebuildBlockCache 차단/해제 후 캐시 유무와 상관없이 라이터 기준으로 다시 만듦
+// 실패하면 오래된 캐시가 남지 않도록 삭제 (다음 조회 때 MySQL에서 읽음)
+func (s *FriendService) rebuildBlockCache(userID string) {
+	blockedIDs, err := s.loadBlockedIDs(userID)
+	if err == nil {
+		err = s.cacheBlockedIDs(userID, blockedIDs)
+	}
+	if err != nil {
+		log.Printf("Failed to rebuild block cache for user %s: %v", userID, err)
+		database.Del(blockCacheKey(userID))
+	}
+}
+
+// GetSettings 친구 설정 조회 (저장된 설정이 없으면 친구에게서만 초대 받음)
+func (s *FriendService) GetSettings(userID string) (*dto.FriendSettingsResponse, error) {
+	var settings domain.FriendSettings
+	err := database.GetReaderDB().Where("user_id = ?", userID).Limit(1).Find(&settings).Error
+	if err != nil {
+		return nil, errors.DBError()
+	}
+	if settings.AllowInvitesFrom == "" {
+		settings.AllowInvitesFrom = INVITES_FROM_FRIENDS
+	}
+	return &dto.FriendSettingsResponse{AllowInvitesFrom: settings.AllowInvitesFrom}, nil
+}
+
+// UpdateSettings 친구 설정 변경
+func (s *FriendService) UpdateSettings(userID string, req *dto.FriendSettingsRequest) (*dto.FriendSettingsResponse, error) {
+	switch req.AllowInvitesFrom {
+	case INVITES_FROM_FRIENDS, INVITES_FROM_EVERYONE, INVITES_FROM_NOBODY:
+	default:
+		return nil, errors.BadRequestWithMessage("allowInvitesFrom은 friends, everyone, nobody 중 하나여야 합니다")
+	}
+
+	settings := &domain.FriendSettings{UserID: userID, AllowInvitesFrom: req.AllowInvitesFrom}
+	if err := database.GetWriterDB().Save(settings).Error; err != nil {
+		return nil, errors.DBError()
+	}
+	return &dto.FriendSettingsResponse{AllowInvitesFrom: settings.AllowInvitesFrom}, nil
+}
+
+// CanInvite 매치 초대 가능 여부 (차단 관계면 불가, 그 외에는 대상의 초대 설정에 따름)
+func (s *FriendService) CanInvite(hostID, targetID string) (bool, error) {
+	if hostID == targetID {
+		return false, nil
+	}
+
+	blocked, err := s.IsBlockedEither(hostID, targetID)
+	if err != nil || blocked {
+		return false, err
+	}
+
+	settings, err := s.GetSettings(targetID)
+	if err != nil {
+		return false, err
+	}
+	switch settings.AllowInvitesFrom {
+	case INVITES_FROM_EVERYONE:
+		return true, nil
+	case INVITES_FROM_NOBODY:
+		return false, nil
+	default:
+		return s.AreFriends(hostID, targetID)
+	}
+}
+
+// publish 상대 사용자에게 친구 알림 발행 (실패해도 요청 처리에는 영향 없음)
+func (s *FriendService) publish(eventType, userID, fromUserID string) {
+	event := dto.FriendEvent{
+		Type:       eventType,
+		UserID:     userID,
+		FromUserID: fromUserID,
+		CreatedAt:  time.Now(),
+	}
+	eventJSON, _ := json.Marshal(event)
+	if err := database.Publish(FRIEND_CHANNEL, string(eventJSON)); err != nil {
+		log.Printf("Failed to publish %s for user %s: %v", eventType, userID, err)
+	}
+}
+
+// findFriendship 방향과 상관없이 두 사용자 사이의 친구 관계 조회 (없으면 nil)
+func findFriendship(db *gorm.DB, userID, otherID string) (*domain.Friendship, error) {
+	var friendships []domain.Friendship
+	lowID, highID := domain.FriendshipPair(userID, otherID)
+	err := db.Where("low_user_id = ? AND high_user_id = ?", lowID, highID).
+		Limit(1).
+		Find(&friendships).Error
+	if err != nil || len(friendships) == 0 {
+		return nil, err
+	}
+	return &friendships[0], nil
+}
+
+func blockCacheKey(userID string) string {
+	return fmt.Sprintf("blocks:%s", userID)
+}
+
+func deleteFriendship(db *gorm.DB, userID, otherID string) *gorm.DB {
+	lowID, highID := domain.FriendshipPair(userID, otherID)
+	return db.Where("low_user_id = ? AND high_user_id = ?", lowID, highID).
+		Delete(&domain.Friendship{})
+}
@@ -1,18 +1,33 @@
 package service
 
 import (
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
 	"game-server/internal/dto"
+	"game-server/internal/pkg/database"
+	"game-server/internal/pkg/errors"
+	"log"
+	"slices"
+	"strings"
 	"time"
+
+	"github.com/google/uuid"
 )
 
 type GameService struct {
+	recorder *EventRecorder
 }
 
 type Game struct {
 	ID          string                 `json:"id"`
+	MatchID     string                 `json:"matchId"`
+	GameID      string                 `json:"gameId"`
+	HostID      string                 `json:"hostId"`
 	Status      string                 `json:"status"`
 	PlayerCount int                    `json:"playerCount"`
 	Teams       []dto.Team             `json:"teams"`
+	Players     []dto.MatchPlayer      `json:"players"`
 	CreatedAt   time.Time              `json:"createdAt"`
 	StartedAt   *time.Time             `json:"startedAt,omitempty"`
 	EndedAt     *time.Time             `json:"endedAt,omitempty"`
@@ -26,6 +41,377 @@ const (
 	GAME_STATUS_ENDED    = "ended"
 )
 
-func NewGameService() *GameService {
-	return &GameService{}
+const (
+	PLAYER_STATUS_PLAYING      = "playing"
+	PLAYER_STATUS_DISCONNECTED = "disconnected"
+)
+
+// GAME_EVENT_END 호스트가 보내면 게임이 종료되는 이벤트 타입
+const GAME_EVENT_END = "game_end"
+
+// 서버만 기록하는 게임 진행 이벤트 타입 (리플레이 상태를 바꾸므로 클라이언트가 보낼 수 없음)
+const (
+	GAME_EVENT_STARTED             = "game_started"
+	GAME_EVENT_ENDED               = "game_ended"
+	GAME_EVENT_PLAYER_DISCONNECTED = "player_disconnected"
+)
+
+// GAME_EVENT_CHAT 게임 내 채팅 이벤트 타입 (차단 관계인 플레이어에게는 전달하지 않음)
+const GAME_EVENT_CHAT = "chat"
+
+func NewGameService(recorder *EventRecorder) *GameService {
+	return &GameService{
+		recorder: recorder,
+	}
+}
+
+// CreateGame 시작된 매치로부터 게임 세션 생성
+func (s *GameService) CreateGame(matchInfo *dto.MatchInfo, teams []dto.Team) (*Game, error) {
+	now := time.Now()
+	game := &Game{
+		ID:        uuid.New().String(),
+		MatchID:   matchInfo.MatchID,
+		GameID:    matchInfo.GameID,
+		HostID:    matchInfo.HostID,
+		Status:    GAME_STATUS_STARTING,
+		Teams:     assignHostToTeam(teams, matchInfo.HostID),
+		Players:   []dto.MatchPlayer{},
+		CreatedAt: now,
+		Metadata:  map[string]interface{}{},
+	}
+
+	// 호스트도 게임 참가자로 포함
+	userIDs := []string{matchInfo.HostID}
+	for _, player := range matchInfo.Players {
+		if player.UserID != matchInfo.HostID {
+			userIDs = append(userIDs, player.UserID)
+		}
+	}
+	for _, userID := range userIDs {
+		game.Players = append(game.Players, dto.MatchPlayer{
+			UserID:   userID,
+			Status:   PLAYER_STATUS_PLAYING,
+			JoinedAt: &now,
+		})
+	}
+	game.PlayerCount = len(game.Players)
+
+	if err := s.UpdateGame(game); err != nil {
+		return nil, errors.DBError()
+	}
+
+	// 사용자를 게임에 연결
+	for _, player := range game.Players {
+		if err := database.HSet("user:games", player.UserID, game.ID); err != nil {
+			return nil, errors.DBError()
+		}
+	}
+
+	return game, nil
+}
+
+// StartGame 게임 세션을 시작 상태로 전환
+func (s *GameService) StartGame(gameID string) (*dto.GameStartedResponse, error) {
+	game, err := s.GetGame(gameID)
+	if err != nil {
+		return nil, err
+	}
+
+	if game.Status != GAME_STATUS_WAITING && game.Status != GAME_STATUS_STARTING {
+		return nil, errors.GameAlreadyStarted()
+	}
+
+	now := time.Now()
+	game.Status = GAME_STATUS_PLAYING
+	game.StartedAt = &now
+
+	if err := s.UpdateGame(game); err != nil {
+		return nil, errors.DBError()
+	}
+
+	s.recordEvent(game.ID, "", GAME_EVENT_STARTED, s.ToGameInfo(game))
+
+	return &dto.GameStartedResponse{
+		GameID: game.ID,
+		Status: game.Status,
+	}, nil
+}
+
+// HandleEvent 참가자가 보낸 게임 이벤트 처리
+func (s *GameService) HandleEvent(userID string, event *dto.GameEventRequest) (*Game, error) {
+	if event.Type == "" {
+		return nil, errors.BadRequestWithMessage("이벤트 type이 필요합니다")
+	}
+	if IsLifecycleEvent(event.Type) {
+		return nil, errors.BadRequestWithMessage(fmt.Sprintf("%s 이벤트는 보낼 수 없습니다", event.Type))
+	}
+
+	game, err := s.GetGameByUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if game.Status != GAME_STATUS_PLAYING {
+		return nil, errors.GameNotInProgress()
+	}
+
+	player := s.findPlayer(game, userID)
+	if player == nil || player.Status != PLAYER_STATUS_PLAYING {
+		return nil, errors.NotInGame()
+	}
+
+	s.recordEvent(game.ID, userID, event.Type, event.Data)
+
+	return game, nil
+}
+
+// DisconnectPlayer 플레이어 연결 해제 처리
+// 한 팀의 모든 플레이어가 나가면 게임이 종료됨
+func (s *GameService) DisconnectPlayer(userID string) (*Game, *dto.PlayerDisconnectedResponse, error) {
+	game, err := s.GetGameByUser(userID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	database.HDel("user:games", userID)
+
+	player := s.findPlayer(game, userID)
+	if player == nil || game.Status == GAME_STATUS_ENDED {
+		return game, nil, nil
+	}
+	player.Status = PLAYER_STATUS_DISCONNECTED
+
+	if err := s.UpdateGame(game); err != nil {
+		return nil, nil, errors.DBError()
+	}
+
+	disconnected := &dto.PlayerDisconnectedResponse{
+		PlayerID: userID,
+		TeamID:   s.GetTeamID(game, userID),
+	}
+	s.recordEvent(game.ID, userID, GAME_EVENT_PLAYER_DISCONNECTED, disconnected)
+
+	return game, disconnected, nil
+}
+
+// ShouldEnd 게임을 계속 진행할 수 없는지 확인
+func (s *GameService) ShouldEnd(game *Game) bool {
+	if game.Status == GAME_STATUS_ENDED {
+		return false
+	}
+
+	for _, team := range game.Teams {
+		connected := 0
+		for _, userID := range team.Players {
+			if player := s.findPlayer(game, userID); player != nil && player.Status == PLAYER_STATUS_PLAYING {
+				connected++
+			}
+		}
+		if len(team.Players) > 0 && connected == 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// EndGame 게임 세션 종료
+func (s *GameService) EndGame(gameID string, reason string) (*dto.GameInfo, error) {
+	game, err := s.GetGame(gameID)
+	if err != nil {
+		return nil, err
+	}
+
+	if game.Status == GAME_STATUS_ENDED {
+		return nil, errors.GameAlreadyEnded()
+	}
+
+	now := time.Now()
+	game.Status = GAME_STATUS_ENDED
+	game.EndedAt = &now
+	if game.Metadata == nil {
+		game.Metadata = map[string]interface{}{}
+	}
+	game.Metadata["endReason"] = reason
+
+	// 종료된 게임은 세션 저장소에서 제거
+	for _, player := range game.Players {
+		if linkedID, err := database.HGet("user:games", player.UserID); err == nil && linkedID == game.ID {
+			database.HDel("user:games", player.UserID)
+		}
+	}
+	database.HDel("games", game.ID)
+
+	gameInfo := s.ToGameInfo(game)
+	s.recordEvent(game.ID, "", GAME_EVENT_ENDED, gameInfo)
+
+	return gameInfo, nil
+}
+
+// QueryEvents 게임 세션의 기록된 이벤트를 조건에 맞게 페이지 단위로 조회
+func (s *GameService) QueryEvents(gameID string, req *dto.GameEventQueryRequest) (*database.EventPage, error) {
+	query := database.EventQuery{
+		Limit:  req.Limit,
+		Cursor: req.Cursor,
+	}
+
+	if req.Types != "" {
+		for _, eventType := range strings.Split(req.Types, ",") {
+			if eventType = strings.TrimSpace(eventType); eventType != "" {
+				query.Types = append(query.Types, eventType)
+			}
+		}
+	}
+
+	switch req.Order {
+	case "", "asc":
+	case "desc":
+		query.Descending = true
+	default:
+		return nil, errors.BadRequestWithMessage("order는 asc 또는 desc만 가능합니다")
+	}
+
+	if req.From != "" {
+		from, err := time.Parse(time.RFC3339, req.From)
+		if err != nil {
+			return nil, errors.BadRequestWithMessage("from은 RFC3339 형식이어야 합니다")
+		}
+		query.From = from
+	}
+	if req.To != "" {
+		to, err := time.Parse(time.RFC3339, req.To)
+		if err != nil {
+			return nil, errors.BadRequestWithMessage("to는 RFC3339 형식이어야 합니다")
+		}
+		query.To = to
+	}
+
+	page, err := database.GetEventStore().QueryByGame(gameID, query)
+	if stderrors.Is(err, database.ErrInvalidCursor) {
+		return nil, errors.BadRequestWithMessage("잘못된 커서입니다")
+	}
+	if err != nil {
+		log.Printf("Failed to query events for game %s: %v", gameID, err)
+		return nil, errors.DBError()
+	}
+
+	return page, nil
+}
+
+// GetGame 게임 세션 조회
+func (s *GameService) GetGame(gameID string) (*Game, error) {
+	gameData, err := database.HGet("games", gameID)
+	if err != nil || gameData == "" {
+		return nil, errors.GameNotFound()
+	}
+
+	var game Game
+	if err := json.Unmarshal([]byte(gameData), &game); err != nil {
+		return nil, errors.InternalServerError()
+	}
+
+	return &game, nil
+}
+
+// GetGameByUser 사용자가 참가 중인 게임 세션 조회
+func (s *GameService) GetGameByUser(userID string) (*Game, error) {
+	gameID, err := database.HGet("user:games", userID)
+	if err != nil || gameID == "" {
+		return nil, errors.NotInGame()
+	}
+	return s.GetGame(gameID)
+}
+
+// UpdateGame 게임 세션 저장
+func (s *GameService) UpdateGame(game *Game) error {
+	gameJSON, err := json.Marshal(game)
+	if err != nil {
+		return err
+	}
+	return database.HSet("games", game.ID, string(gameJSON))
+}
+
+// GetTeamID 플레이어의 팀 ID 조회 (팀이 없으면 0)
+func (s *GameService) GetTeamID(game *Game, userID string) int {
+	for _, team := range game.Teams {
+		for _, playerID := range team.Players {
+			if playerID == userID {
+				return team.ID
+			}
+		}
+	}
+	return 0
+}
+
+// ToGameInfo 게임 세션을 응답용 DTO로 변환
+func (s *GameService) ToGameInfo(game *Game) *dto.GameInfo {
+	metadata := map[string]interface{}{
+		"matchId": game.MatchID,
+		"gameId":  game.GameID,
+		"hostId":  game.HostID,
+	}
+	for key, value := range game.Metadata {
+		metadata[key] = value
+	}
+
+	return &dto.GameInfo{
+		ID:          game.ID,
+		Status:      game.Status,
+		PlayerCount: game.PlayerCount,
+		Teams:       game.Teams,
+		Players:     game.Players,
+		CreatedAt:   game.CreatedAt,
+		StartedAt:   game.StartedAt,
+		EndedAt:     game.EndedAt,
+		Metadata:    metadata,
+	}
+}
+
+// recordEvent 이벤트 기록 실패는 게임 진행을 막지 않음
+func (s *GameService) recordEvent(gameID, userID, eventType string, data interface{}) {
+	if s.recorder == nil {
+		return
+	}
+	if err := s.recorder.Record(gameID, userID, eventType, data); err != nil {
+		log.Printf("Failed to record %s event for game %s: %v", eventType, gameID, err)
+	}
+}
+
+// IsLifecycleEvent 서버만 기록할 수 있는 게임 진행 이벤트인지 확인
+func IsLifecycleEvent(eventType string) bool {
+	switch eventType {
+	case GAME_EVENT_STARTED, GAME_EVENT_ENDED, GAME_EVENT_PLAYER_DISCONNECTED:
+		return true
+	}
+	return false
+}
+
+// assignHostToTeam 매치 플레이어 목록에 없는 호스트를 인원이 가장 적은 팀에 배정
+// 팀이 없는 참가자는 ShouldEnd 판단에서 빠지므로 호스트도 반드시 팀에 속해야 함
+func assignHostToTeam(teams []dto.Team, hostID string) []dto.Team {
+	assigned := make([]dto.Team, len(teams))
+	smallest := -1
+	for i, team := range teams {
+		assigned[i] = dto.Team{ID: team.ID, Players: slices.Clone(team.Players)}
+		if slices.Contains(team.Players, hostID) {
+			return teams
+		}
+		if smallest < 0 || len(team.Players) < len(teams[smallest].Players) {
+			smallest = i
+		}
+	}
+	if smallest < 0 {
+		return teams
+	}
+	assigned[smallest].Players = append(assigned[smallest].Players, hostID)
+	return assigned
+}
+
+func (s *GameService) findPlayer(game *Game, userID string) *dto.MatchPlayer {
+	for i := range game.Players {
+		if game.Players[i].UserID == userID {
+			return &game.Players[i]
+		}
+	}
+	return nil
 }
@@ -0,0 +1,70 @@
+package service
+
+import (
+	"game-server/internal/dto"
+	"slices"
+	"testing"
+)
+
+func TestAssignHostToTeam(t *testing.T) {
+	teams := []dto.Team{
+		{ID: 1, Players: []string{"a", "b"}},
+		{ID: 2, Players: []string{"c"}},
+	}
+
+	assigned := assignHostToTeam(teams, "host")
+
+	if !slices.Contains(assigned[1].Players, "host") {
+		t.Fatalf("host should join the smaller team, got %+v", assigned)
+	}
+	if slices.Contains(teams[1].Players, "host") {
+		t.Fatal("original teams must not be modified")
+	}
+
+	// 이미 팀에 있으면 그대로 유지
+	again := assignHostToTeam(assigned, "host")
+	count := 0
+	for _, team := range again {
+		for _, playerID := range team.Players {
+			if playerID == "host" {
+				count++
+			}
+		}
+	}
+	if count != 1 {
+		t.Fatalf("host assigned %d times", count)
+	}
+}
+
+func TestShouldEndCountsHost(t *testing.T) {
+	service := &GameService{}
+	game := &Game{
+		Status: GAME_STATUS_PLAYING,
+		Teams:  assignHostToTeam([]dto.Team{{ID: 1, Players: []string{"a"}}, {ID: 2, Players: []string{}}}, "host"),
+		Players: []dto.MatchPlayer{
+			{UserID: "host", Status: PLAYER_STATUS_PLAYING},
+			{UserID: "a", Status: PLAYER_STATUS_PLAYING},
+		},
+	}
+
+	if service.GetTeamID(game, "host") != 2 {
+		t.Fatalf("host team = %d, want 2", service.GetTeamID(game, "host"))
+	}
+	if service.ShouldEnd(game) {
+		t.Fatal("game should continue while both teams have players")
+	}
+
+	game.Players[0].Status = PLAYER_STATUS_DISCONNECTED
+	if !service.ShouldEnd(game) {
+		t.Fatal("game should end when the host's team has no connected players")
+	}
+}
+
+func TestHandleEventRejectsLifecycleTypes(t *testing.T) {
+	service := &GameService{}
+	for _, eventType := range []string{GAME_EVENT_STARTED, GAME_EVENT_ENDED, GAME_EVENT_PLAYER_DISCONNECTED} {
+		if _, err := service.HandleEvent("user", &dto.GameEventRequest{Type: eventType}); err == nil {
+			t.Errorf("%s event from a client should be rejected", eventType)
+		}
+	}
+}
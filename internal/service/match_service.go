@@ -5,25 +5,33 @@ import (
 	"fmt"
 	"game-server/internal/dto"
 	"game-server/internal/pkg/database"
+	"game-server/internal/pkg/errors"
+	"sort"
 	"time"
 
 	"github.com/google/uuid"
 )
 
-type MatchService struct{}
+type MatchService struct {
+	sessionService *SessionService
+	friendService  *FriendService
+}
 
-func NewMatchService() *MatchService {
-	return &MatchService{}
+func NewMatchService(sessionService *SessionService, friendService *FriendService) *MatchService {
+	return &MatchService{
+		sessionService: sessionService,
+		friendService:  friendService,
+	}
 }
 
 const (
 	INVITE_EXPIRE_MINUTES = 5
 )
 
-// CreateMatch 새로운 매치 생성
-func (s *MatchService) CreateMatch(hostID, gameID string, maxPlayers int) (*dto.MatchInfo, error) {
+// CreateMatch 새로운 매치 생성 (public이면 공개 로비 목록에 노출되고 초대 없이 참가 가능)
+func (s *MatchService) CreateMatch(hostID, gameID string, maxPlayers int, public bool) (*dto.MatchInfo, error) {
 	if gameID == "" || maxPlayers < 2 {
-		return nil, fmt.Errorf("gameId and valid maxPlayers are required")
+		return nil, errors.BadRequestWithMessage("gameId와 2 이상의 maxPlayers가 필요합니다")
 	}
 
 	matchID := uuid.New().String()
@@ -36,18 +44,19 @@ func (s *MatchService) CreateMatch(hostID, gameID string, maxPlayers int) (*dto.
 		Status:     "waiting",
 		Players:    []dto.MatchPlayer{},
 		MaxPlayers: maxPlayers,
+		Public:     public,
 		CreatedAt:  time.Now(),
 	}
 
 	// Redis에 매치 정보 저장
 	matchJSON, _ := json.Marshal(matchInfo)
 	if err := database.HSet("matches", matchID, string(matchJSON)); err != nil {
-		return nil, fmt.Errorf("failed to create match: %w", err)
+		return nil, errors.DBError()
 	}
 
 	// 사용자를 매치에 연결
 	if err := database.HSet("user:matches", hostID, matchID); err != nil {
-		return nil, fmt.Errorf("failed to link user to match: %w", err)
+		return nil, errors.DBError()
 	}
 
 	return matchInfo, nil
@@ -56,18 +65,18 @@ func (s *MatchService) CreateMatch(hostID, gameID string, maxPlayers int) (*dto.
 // InviteFriends 친구들을 매치에 초대
 func (s *MatchService) InviteFriends(hostID, matchID string, friendIds []string) (*dto.InviteFriendResponse, error) {
 	if matchID == "" || len(friendIds) == 0 {
-		return nil, fmt.Errorf("matchId and friendIds are required")
+		return nil, errors.BadRequestWithMessage("matchId와 friendIds가 필요합니다")
 	}
 
 	// 매치 정보 확인
 	matchInfo, err := s.GetMatchInfo(matchID)
 	if err != nil {
-		return nil, fmt.Errorf("match not found")
+		return nil, errors.MatchNotFound()
 	}
 
 	// 호스트 권한 확인
 	if matchInfo.HostID != hostID {
-		return nil, fmt.Errorf("only host can invite friends")
+		return nil, errors.NotHost()
 	}
 
 	var invitedIds []string
@@ -75,9 +84,16 @@ func (s *MatchService) InviteFriends(hostID, matchID string, friendIds []string)
 	expiresAt := time.Now().Add(INVITE_EXPIRE_MINUTES * time.Minute).Unix()
 
 	for _, friendID := range friendIds {
-		// 친구가 온라인인지 확인
-		_, err := database.HGet("user:sockets", friendID)
-		if err != nil {
+		// 친구 관계 또는 상대의 초대 설정 확인 (차단 관계면 항상 실패)
+		allowed, err := s.friendService.CanInvite(hostID, friendID)
+		if err != nil || !allowed {
+			failedIds = append(failedIds, friendID)
+			continue
+		}
+
+		// 친구가 온라인인지 확인 (기기 종류와 상관없이 세션이 하나라도 있으면 초대)
+		online, err := s.sessionService.IsOnline(friendID)
+		if err != nil || !online {
 			failedIds = append(failedIds, friendID)
 			continue
 		}
@@ -112,14 +128,14 @@ func (s *MatchService) InviteFriends(hostID, matchID string, friendIds []string)
 // RespondInvite 초대에 응답
 func (s *MatchService) RespondInvite(userID, matchID, response string) (*dto.InviteResponseResponse, error) {
 	if matchID == "" || (response != "accept" && response != "decline") {
-		return nil, fmt.Errorf("matchId and valid response (accept/decline) are required")
+		return nil, errors.BadRequestWithMessage("matchId와 accept 또는 decline 응답이 필요합니다")
 	}
 
 	// 초대 정보 확인
 	inviteKey := fmt.Sprintf("invite:%s:%s", matchID, userID)
 	inviteData, err := database.Get(inviteKey)
 	if err != nil || inviteData == "" {
-		return nil, fmt.Errorf("invitation not found or expired")
+		return nil, errors.InviteExpired()
 	}
 
 	// 초대 삭제
@@ -139,12 +155,88 @@ func (s *MatchService) RespondInvite(userID, matchID, response string) (*dto.Inv
 	// accept인 경우 매치에 참가
 	matchInfo, err := s.GetMatchInfo(matchID)
 	if err != nil {
-		return nil, fmt.Errorf("match not found")
+		return nil, errors.MatchNotFound()
+	}
+	if err := s.joinMatch(userID, matchInfo); err != nil {
+		return nil, err
+	}
+
+	result.Message = "Successfully joined the match"
+	return result, nil
+}
+
+// ListLobbies 초대 없이 참가할 수 있는 공개 로비 목록 (gameID가 비어 있으면 모든 게임)
+// 조회한 사용자와 차단 관계인 호스트나 플레이어가 있는 로비는 목록에서 제외
+func (s *MatchService) ListLobbies(userID, gameID string) ([]dto.MatchInfo, error) {
+	matches, err := database.HGetAll("matches")
+	if err != nil {
+		return nil, errors.DBError()
 	}
 
+	lobbies := []dto.MatchInfo{}
+	for _, matchData := range matches {
+		var matchInfo dto.MatchInfo
+		if err := json.Unmarshal([]byte(matchData), &matchInfo); err != nil {
+			continue
+		}
+		if !isOpenLobby(&matchInfo) || (gameID != "" && matchInfo.GameID != gameID) {
+			continue
+		}
+
+		blocked, err := s.friendService.BlockedAmong(userID, matchMemberIDs(&matchInfo))
+		if err != nil {
+			return nil, errors.DBError()
+		}
+		if len(blocked) > 0 {
+			continue
+		}
+		lobbies = append(lobbies, matchInfo)
+	}
+
+	sort.Slice(lobbies, func(i, j int) bool {
+		return lobbies[i].CreatedAt.Before(lobbies[j].CreatedAt)
+	})
+	return lobbies, nil
+}
+
+// JoinLobby 공개 로비에 초대 없이 참가
+func (s *MatchService) JoinLobby(userID, matchID string) (*dto.MatchInfo, error) {
+	if matchID == "" {
+		return nil, errors.BadRequestWithMessage("matchId가 필요합니다")
+	}
+
+	matchInfo, err := s.GetMatchInfo(matchID)
+	if err != nil {
+		return nil, errors.MatchNotFound()
+	}
+	// 비공개 매치는 없는 매치처럼 처리
+	if !matchInfo.Public {
+		return nil, errors.MatchNotFound()
+	}
+	if matchInfo.Status != "waiting" {
+		return nil, errors.GameAlreadyStarted()
+	}
+
+	if err := s.joinMatch(userID, matchInfo); err != nil {
+		return nil, err
+	}
+	return matchInfo, nil
+}
+
+// joinMatch 인원과 차단 관계를 확인한 뒤 플레이어로 추가
+func (s *MatchService) joinMatch(userID string, matchInfo *dto.MatchInfo) error {
 	// 매치가 가득 찼는지 확인
 	if len(matchInfo.Players) >= matchInfo.MaxPlayers {
-		return nil, fmt.Errorf("match is full")
+		return errors.MatchFull()
+	}
+
+	// 호스트나 이미 참가한 플레이어와 차단 관계면 참가 불가
+	blocked, err := s.friendService.BlockedAmong(userID, matchMemberIDs(matchInfo))
+	if err != nil {
+		return errors.DBError()
+	}
+	if len(blocked) > 0 {
+		return errors.UserBlocked()
 	}
 
 	// 플레이어 추가
@@ -158,14 +250,28 @@ func (s *MatchService) RespondInvite(userID, matchID, response string) (*dto.Inv
 
 	// 매치 정보 업데이트
 	if err := s.UpdateMatchInfo(matchInfo); err != nil {
-		return nil, fmt.Errorf("failed to update match")
+		return errors.DBError()
 	}
 
 	// 사용자를 매치에 연결
-	database.HSet("user:matches", userID, matchID)
+	database.HSet("user:matches", userID, matchInfo.MatchID)
+	return nil
+}
 
-	result.Message = "Successfully joined the match"
-	return result, nil
+// isOpenLobby 공개되어 있고 아직 시작 전이며 자리가 남은 매치인지 확인
+func isOpenLobby(matchInfo *dto.MatchInfo) bool {
+	return matchInfo.Public && matchInfo.Status == "waiting" && len(matchInfo.Players) < matchInfo.MaxPlayers
+}
+
+// matchMemberIDs 매치의 호스트와 플레이어 ID (호스트는 플레이어 목록에 없을 수 있음)
+func matchMemberIDs(matchInfo *dto.MatchInfo) []string {
+	memberIDs := []string{matchInfo.HostID}
+	for _, player := range matchInfo.Players {
+		if player.UserID != matchInfo.HostID {
+			memberIDs = append(memberIDs, player.UserID)
+		}
+	}
+	return memberIDs
 }
 
 // StartMatch 매치 시작
@@ -173,17 +279,17 @@ func (s *MatchService) StartMatch(hostID, matchID string) (*dto.StartMatchRespon
 	// 매치 정보 확인
 	matchInfo, err := s.GetMatchInfo(matchID)
 	if err != nil {
-		return nil, fmt.Errorf("match not found")
+		return nil, errors.MatchNotFound()
 	}
 
 	// 호스트 권한 확인
 	if matchInfo.HostID != hostID {
-		return nil, fmt.Errorf("only host can start the match")
+		return nil, errors.NotHost()
 	}
 
 	// 최소 플레이어 수 확인
 	if len(matchInfo.Players) < 2 {
-		return nil, fmt.Errorf("need at least 2 players to start")
+		return nil, errors.NotEnoughPlayers()
 	}
 
 	// 매치 상태 업데이트
@@ -191,12 +297,12 @@ func (s *MatchService) StartMatch(hostID, matchID string) (*dto.StartMatchRespon
 	matchInfo.Status = "starting"
 	matchInfo.StartedAt = &now
 
-	// 팀 생성 (간단하게 절반씩 나누기)
+	// 팀 생성 (절반씩 나누되 차단 관계인 플레이어는 가능한 한 다른 팀으로)
 	teams := s.CreateTeams(matchInfo.Players)
 
 	// 매치 정보 업데이트
 	if err := s.UpdateMatchInfo(matchInfo); err != nil {
-		return nil, fmt.Errorf("failed to update match")
+		return nil, errors.DBError()
 	}
 
 	return &dto.StartMatchResponse{
@@ -212,7 +318,7 @@ func (s *MatchService) LeaveMatch(userID string) error {
 	// 사용자의 현재 매치 확인
 	matchID, err := database.HGet("user:matches", userID)
 	if err != nil || matchID == "" {
-		return fmt.Errorf("you are not in any match")
+		return errors.NotInMatch()
 	}
 
 	return s.RemovePlayerFromMatch(userID, matchID)
@@ -222,12 +328,12 @@ func (s *MatchService) LeaveMatch(userID string) error {
 func (s *MatchService) GetMatchInfo(matchID string) (*dto.MatchInfo, error) {
 	matchData, err := database.HGet("matches", matchID)
 	if err != nil || matchData == "" {
-		return nil, fmt.Errorf("match not found")
+		return nil, errors.MatchNotFound()
 	}
 
 	var matchInfo dto.MatchInfo
 	if err := json.Unmarshal([]byte(matchData), &matchInfo); err != nil {
-		return nil, fmt.Errorf("invalid match data")
+		return nil, errors.InternalServerError()
 	}
 
 	return &matchInfo, nil
@@ -273,19 +379,45 @@ func (s *MatchService) RemovePlayerFromMatch(userID, matchID string) error {
 }
 
 // CreateTeams 팀 생성
+// 번갈아 배치하되, 이미 배치된 팀에 차단 관계인 플레이어가 더 많으면 자리가 남은 다른 팀으로 배치
+// 차단 목록을 확인하지 못하면 번갈아 배치만 함
 func (s *MatchService) CreateTeams(players []dto.MatchPlayer) []dto.Team {
 	teams := make([]dto.Team, 2)
 	teams[0] = dto.Team{ID: 1, Players: []string{}}
 	teams[1] = dto.Team{ID: 2, Players: []string{}}
+	teamSize := (len(players) + 1) / 2
 
+	var placed []string
 	for i, player := range players {
 		teamIndex := i % 2
+		other := 1 - teamIndex
+
+		if blocked, err := s.friendService.BlockedAmong(player.UserID, placed); err == nil && len(blocked) > 0 {
+			if countBlocked(teams[other], blocked) < countBlocked(teams[teamIndex], blocked) && len(teams[other].Players) < teamSize {
+				teamIndex, other = other, teamIndex
+			}
+		}
+		if len(teams[teamIndex].Players) >= teamSize {
+			teamIndex = other
+		}
+
 		teams[teamIndex].Players = append(teams[teamIndex].Players, player.UserID)
+		placed = append(placed, player.UserID)
 	}
 
 	return teams
 }
 
+func countBlocked(team dto.Team, blocked map[string]bool) int {
+	count := 0
+	for _, userID := range team.Players {
+		if blocked[userID] {
+			count++
+		}
+	}
+	return count
+}
+
 // GetMatchPlayers 매치의 플레이어 목록 조회
 func (s *MatchService) GetMatchPlayers(matchID string) ([]dto.MatchPlayer, error) {
 	matchInfo, err := s.GetMatchInfo(matchID)
@@ -0,0 +1,115 @@
+package service
+
+import (
+	"fmt"
+	"game-server/internal/dto"
+	"game-server/internal/pkg/database"
+	"game-server/internal/pkg/database/redistest"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+)
+
+// cacheBlocks 사용자별 차단 목록 캐시를 채워서 MySQL 없이 차단 관계를 확인
+func cacheBlocks(redis *miniredis.Miniredis, blocks map[string][]string) {
+	for userID, blockedIDs := range blocks {
+		redis.SAdd(blockCacheKey(userID), append(blockedIDs, blockCacheMarker)...)
+	}
+}
+
+func newTestMatchService() *MatchService {
+	return NewMatchService(NewSessionService(SESSION_POLICY_MULTI), NewFriendService())
+}
+
+func invite(t *testing.T, matchID, userID string) {
+	t.Helper()
+	if err := database.Set(fmt.Sprintf("invite:%s:%s", matchID, userID), `{"matchId":"`+matchID+`"}`, time.Minute); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRespondInviteRejectsUserBlockedByHost(t *testing.T) {
+	redis := redistest.Start(t)
+	s := newTestMatchService()
+	cacheBlocks(redis, map[string][]string{"host": nil, "friend": nil, "invitee": nil})
+
+	match, err := s.CreateMatch("host", "battle-arena", 4, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	invite(t, match.MatchID, "friend")
+	invite(t, match.MatchID, "invitee")
+
+	if _, err := s.RespondInvite("friend", match.MatchID, "accept"); err != nil {
+		t.Fatalf("invite without a block should be accepted: %v", err)
+	}
+
+	// 초대 후 수락 전에 호스트가 차단 (호스트는 플레이어 목록에 없음)
+	redis.SAdd(blockCacheKey("host"), "invitee")
+	_, err = s.RespondInvite("invitee", match.MatchID, "accept")
+	assertErrorCode(t, err, "USER_BLOCKED")
+
+	players, _ := s.GetMatchPlayers(match.MatchID)
+	if len(players) != 1 || players[0].UserID != "friend" {
+		t.Fatalf("blocked user should not join, players = %+v", players)
+	}
+}
+
+func TestListLobbiesHidesBlockedUsers(t *testing.T) {
+	redis := redistest.Start(t)
+	s := newTestMatchService()
+	cacheBlocks(redis, map[string][]string{
+		"viewer":     nil,
+		"open-host":  nil,
+		"block-host": {"viewer"},
+		"private":    nil,
+		"guest-host": nil,
+		"guest":      {"viewer"},
+	})
+
+	open, _ := s.CreateMatch("open-host", "battle-arena", 4, true)
+	s.CreateMatch("block-host", "battle-arena", 4, true)
+	s.CreateMatch("private", "battle-arena", 4, false)
+	withGuest, _ := s.CreateMatch("guest-host", "battle-arena", 4, true)
+	if _, err := s.JoinLobby("guest", withGuest.MatchID); err != nil {
+		t.Fatal(err)
+	}
+
+	lobbies, err := s.ListLobbies("viewer", "battle-arena")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(lobbies) != 1 || lobbies[0].MatchID != open.MatchID {
+		t.Fatalf("lobbies = %+v, want only the open lobby without blocked users", lobbies)
+	}
+	if lobbies, _ := s.ListLobbies("viewer", "other-game"); len(lobbies) != 0 {
+		t.Fatalf("lobbies of another game = %+v", lobbies)
+	}
+
+	_, err = s.JoinLobby("viewer", withGuest.MatchID)
+	assertErrorCode(t, err, "USER_BLOCKED")
+}
+
+func TestCreateTeamsSeparatesBlockedPlayers(t *testing.T) {
+	redis := redistest.Start(t)
+	s := newTestMatchService()
+	cacheBlocks(redis, map[string][]string{"a": {"c"}, "b": nil, "c": nil, "d": nil})
+
+	players := []dto.MatchPlayer{{UserID: "a"}, {UserID: "b"}, {UserID: "c"}, {UserID: "d"}}
+	teams := s.CreateTeams(players)
+	if slices.Contains(teams[0].Players, "a") == slices.Contains(teams[0].Players, "c") {
+		t.Fatalf("blocked players should be on different teams: %+v", teams)
+	}
+	if len(teams[0].Players) != 2 || len(teams[1].Players) != 2 {
+		t.Fatalf("teams should stay balanced: %+v", teams)
+	}
+
+	// 차단 관계가 없으면 번갈아 배치
+	redis.SRem(blockCacheKey("a"), "c")
+	teams = s.CreateTeams(players)
+	if !slices.Equal(teams[0].Players, []string{"a", "c"}) || !slices.Equal(teams[1].Players, []string{"b", "d"}) {
+		t.Fatalf("teams without blocks = %+v", teams)
+	}
+}
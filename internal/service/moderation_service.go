@@ -0,0 +1,174 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"game-server/internal/dto"
+	"game-server/internal/pkg/auth"
+	"game-server/internal/pkg/database"
+	"game-server/internal/pkg/errors"
+	"log"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// BAN_CHANNEL 차단 발생 시 모든 서버 노드에 알리는 Redis 채널
+	BAN_CHANNEL = "moderation:bans"
+
+	// REVOCATION_CHANNEL 토큰/세션 폐기 시 모든 서버 노드에 알리는 Redis 채널
+	REVOCATION_CHANNEL = "moderation:revocations"
+
+	// 만료 시각을 모르는 토큰을 폐기할 때 사용하는 TTL
+	DEFAULT_REVOCATION_TTL = 24 * time.Hour
+)
+
+// ModerationService 토큰 폐기 목록과 사용자 차단 기록 관리 (Redis)
+type ModerationService struct{}
+
+func NewModerationService() *ModerationService {
+	return &ModerationService{}
+}
+
+// CheckClaims 폐기된 토큰/세션이거나 차단된 사용자인지 확인
+// Redis 장애 시에는 인증 자체를 막지 않도록 통과시킴
+func (s *ModerationService) CheckClaims(claims *auth.Claims) error {
+	if claims.ID != "" {
+		if revoked, err := database.Exists(revokedTokenKey(claims.ID)); err != nil {
+			log.Printf("Failed to check token revocation: %v", err)
+		} else if revoked {
+			return errors.TokenRevoked()
+		}
+	}
+
+	if claims.SessionID != "" {
+		if revoked, err := database.Exists(revokedSessionKey(claims.SessionID)); err != nil {
+			log.Printf("Failed to check session revocation: %v", err)
+		} else if revoked {
+			return errors.TokenRevoked()
+		}
+	}
+
+	ban, err := s.GetBan(claims.UserID)
+	if err != nil {
+		log.Printf("Failed to check ban for user %s: %v", claims.UserID, err)
+		return nil
+	}
+	if ban != nil {
+		return errors.UserBanned(ban.Reason)
+	}
+
+	return nil
+}
+
+// RevokeToken 토큰(jti)이나 세션(sid)을 남은 수명 동안 폐기 처리 후 모든 노드에 알려서 해당 토큰으로 접속 중인 소켓을 끊음
+func (s *ModerationService) RevokeToken(req *dto.RevokeTokenRequest) error {
+	if req.JTI == "" && req.SessionID == "" {
+		return errors.BadRequestWithMessage("jti 또는 sessionId가 필요합니다")
+	}
+
+	ttl := DEFAULT_REVOCATION_TTL
+	if req.ExpiresAt > 0 {
+		ttl = time.Until(time.Unix(req.ExpiresAt, 0))
+		if ttl <= 0 {
+			// 이미 만료된 토큰은 기록할 필요 없음
+			return nil
+		}
+	}
+
+	if req.JTI != "" {
+		if err := database.Set(revokedTokenKey(req.JTI), "1", ttl); err != nil {
+			return errors.DBError()
+		}
+	}
+	if req.SessionID != "" {
+		if err := database.Set(revokedSessionKey(req.SessionID), "1", ttl); err != nil {
+			return errors.DBError()
+		}
+	}
+
+	revocationJSON, _ := json.Marshal(req)
+	if err := database.Publish(REVOCATION_CHANNEL, string(revocationJSON)); err != nil {
+		log.Printf("Failed to publish token revocation: %v", err)
+	}
+
+	return nil
+}
+
+// BanUser 사용자 차단 후 모든 노드에 알려서 접속 중인 소켓을 끊음
+func (s *ModerationService) BanUser(req *dto.BanUserRequest, bannedBy string) (*dto.BanInfo, error) {
+	if req.DurationSeconds < 0 {
+		return nil, errors.BadRequestWithMessage("durationSeconds는 0 이상이어야 합니다")
+	}
+
+	ban := &dto.BanInfo{
+		UserID:   req.UserID,
+		Reason:   req.Reason,
+		BannedBy: bannedBy,
+		BannedAt: time.Now(),
+	}
+
+	var ttl time.Duration
+	if req.DurationSeconds > 0 {
+		ttl = time.Duration(req.DurationSeconds) * time.Second
+		expiresAt := ban.BannedAt.Add(ttl)
+		ban.ExpiresAt = &expiresAt
+	}
+
+	banJSON, _ := json.Marshal(ban)
+	if err := database.Set(banKey(req.UserID), string(banJSON), ttl); err != nil {
+		return nil, errors.DBError()
+	}
+
+	if err := database.Publish(BAN_CHANNEL, string(banJSON)); err != nil {
+		log.Printf("Failed to publish ban for user %s: %v", req.UserID, err)
+	}
+
+	return ban, nil
+}
+
+// UnbanUser 사용자 차단 해제
+func (s *ModerationService) UnbanUser(userID string) error {
+	ban, err := s.GetBan(userID)
+	if err != nil {
+		return errors.DBError()
+	}
+	if ban == nil {
+		return errors.NotFound()
+	}
+
+	if err := database.Del(banKey(userID)); err != nil {
+		return errors.DBError()
+	}
+	return nil
+}
+
+// GetBan 사용자 차단 정보 조회 (차단되지 않았으면 nil)
+func (s *ModerationService) GetBan(userID string) (*dto.BanInfo, error) {
+	banData, err := database.Get(banKey(userID))
+	if err == redis.Nil {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var ban dto.BanInfo
+	if err := json.Unmarshal([]byte(banData), &ban); err != nil {
+		return nil, fmt.Errorf("invalid ban data")
+	}
+	return &ban, nil
+}
+
+func revokedTokenKey(jti string) string {
+	return fmt.Sprintf("revoked:jti:%s", jti)
+}
+
+func revokedSessionKey(sessionID string) string {
+	return fmt.Sprintf("revoked:sid:%s", sessionID)
+}
+
+func banKey(userID string) string {
+	return fmt.Sprintf("ban:%s", userID)
+}
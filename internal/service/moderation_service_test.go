@@ -0,0 +1,111 @@
+package service
+
+import (
+	"game-server/internal/dto"
+	"game-server/internal/pkg/auth"
+	"game-server/internal/pkg/database/redistest"
+	"game-server/internal/pkg/errors"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func testClaims(userID, jti, sessionID string) *auth.Claims {
+	return &auth.Claims{
+		UserID:           userID,
+		SessionID:        sessionID,
+		RegisteredClaims: jwt.RegisteredClaims{ID: jti},
+	}
+}
+
+func assertErrorCode(t *testing.T, err error, code string) {
+	t.Helper()
+	appErr, ok := err.(*errors.AppError)
+	if !ok || appErr.Code != code {
+		t.Fatalf("error = %v, want %s", err, code)
+	}
+}
+
+func TestCheckClaimsRejectsRevokedTokens(t *testing.T) {
+	redistest.Start(t)
+	s := NewModerationService()
+
+	if err := s.CheckClaims(testClaims("user-1", "jti-1", "sid-1")); err != nil {
+		t.Fatalf("token before revocation should pass: %v", err)
+	}
+
+	if err := s.RevokeToken(&dto.RevokeTokenRequest{JTI: "jti-1"}); err != nil {
+		t.Fatal(err)
+	}
+	assertErrorCode(t, s.CheckClaims(testClaims("user-1", "jti-1", "")), "TOKEN_REVOKED")
+	if err := s.CheckClaims(testClaims("user-1", "jti-2", "sid-1")); err != nil {
+		t.Fatalf("other tokens of the user should still pass: %v", err)
+	}
+
+	// 세션을 폐기하면 그 세션에서 발급된 모든 토큰이 거부됨
+	if err := s.RevokeToken(&dto.RevokeTokenRequest{SessionID: "sid-1"}); err != nil {
+		t.Fatal(err)
+	}
+	assertErrorCode(t, s.CheckClaims(testClaims("user-1", "jti-3", "sid-1")), "TOKEN_REVOKED")
+}
+
+func TestRevocationLastsForTokenLifetime(t *testing.T) {
+	redis := redistest.Start(t)
+	s := NewModerationService()
+
+	expiresAt := time.Now().Add(10 * time.Minute).Unix()
+	if err := s.RevokeToken(&dto.RevokeTokenRequest{JTI: "jti-1", ExpiresAt: expiresAt}); err != nil {
+		t.Fatal(err)
+	}
+	if ttl := redis.TTL(revokedTokenKey("jti-1")); ttl <= 0 || ttl > 10*time.Minute {
+		t.Fatalf("revocation ttl = %v, want the token's remaining lifetime", ttl)
+	}
+
+	redis.FastForward(11 * time.Minute)
+	if redis.Exists(revokedTokenKey("jti-1")) {
+		t.Fatal("revocation entry should expire with the token")
+	}
+}
+
+func TestCheckClaimsRejectsBannedUser(t *testing.T) {
+	redistest.Start(t)
+	s := NewModerationService()
+
+	if _, err := s.BanUser(&dto.BanUserRequest{UserID: "user-1", Reason: "cheating"}, "admin-1"); err != nil {
+		t.Fatal(err)
+	}
+	assertErrorCode(t, s.CheckClaims(testClaims("user-1", "jti-1", "")), "USER_BANNED")
+	if err := s.CheckClaims(testClaims("user-2", "jti-2", "")); err != nil {
+		t.Fatalf("other users should pass: %v", err)
+	}
+
+	if err := s.UnbanUser("user-1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.CheckClaims(testClaims("user-1", "jti-1", "")); err != nil {
+		t.Fatalf("unbanned user should pass: %v", err)
+	}
+}
+
+func TestTemporaryBanExpires(t *testing.T) {
+	redis := redistest.Start(t)
+	s := NewModerationService()
+
+	ban, err := s.BanUser(&dto.BanUserRequest{UserID: "user-1", Reason: "spam", DurationSeconds: 60}, "admin-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ban.ExpiresAt == nil {
+		t.Fatal("temporary ban should have an expiry time")
+	}
+	assertErrorCode(t, s.CheckClaims(testClaims("user-1", "", "")), "USER_BANNED")
+
+	redis.FastForward(61 * time.Second)
+	if err := s.CheckClaims(testClaims("user-1", "", "")); err != nil {
+		t.Fatalf("ban should have expired: %v", err)
+	}
+	if ban, _ := s.GetBan("user-1"); ban != nil {
+		t.Fatalf("expired ban still readable: %+v", ban)
+	}
+}
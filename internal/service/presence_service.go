@@ -0,0 +1,156 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"game-server/internal/dto"
+	"game-server/internal/pkg/database"
+	"log"
+	"time"
+)
+
+const (
+	// PRESENCE_CHANNEL 접속 상태 변경을 모든 서버 노드에 알리는 Redis 채널
+	PRESENCE_CHANNEL = "presence:changes"
+)
+
+// 접속 상태
+const (
+	PRESENCE_ONLINE   = "online"
+	PRESENCE_IN_LOBBY = "in_lobby"
+	PRESENCE_IN_GAME  = "in_game"
+	PRESENCE_AWAY     = "away"
+	PRESENCE_OFFLINE  = "offline"
+)
+
+// PresenceService 사용자 접속 상태 관리
+// 상태는 세션/매치/게임 정보에서 계산하고, 바뀌었을 때만 PRESENCE_CHANNEL로 발행
+type PresenceService struct {
+	sessionService *SessionService
+	matchService   *MatchService
+	gameService    *GameService
+}
+
+func NewPresenceService(sessionService *SessionService, matchService *MatchService, gameService *GameService) *PresenceService {
+	return &PresenceService{
+		sessionService: sessionService,
+		matchService:   matchService,
+		gameService:    gameService,
+	}
+}
+
+// Get 사용자의 현재 접속 상태
+// 게임 중 > 로비 > 자리 비움 > 온라인 순으로 판단
+func (s *PresenceService) Get(userID string) (*dto.Presence, error) {
+	sessions, err := s.sessionService.Presence(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	presence := &dto.Presence{
+		UserID:    userID,
+		State:     PRESENCE_OFFLINE,
+		Devices:   sessions.Devices,
+		UpdatedAt: time.Now(),
+	}
+	if !sessions.Online {
+		return presence, nil
+	}
+
+	if game, err := s.gameService.GetGameByUser(userID); err == nil && game.Status == GAME_STATUS_PLAYING {
+		presence.State = PRESENCE_IN_GAME
+		presence.Activity = &dto.PresenceActivity{
+			GameID:      game.GameID,
+			PlayerCount: game.PlayerCount,
+		}
+		return presence, nil
+	}
+
+	if matchID, err := database.HGet("user:matches", userID); err == nil && matchID != "" {
+		if matchInfo, err := s.matchService.GetMatchInfo(matchID); err == nil {
+			presence.State = PRESENCE_IN_LOBBY
+			presence.Activity = &dto.PresenceActivity{
+				GameID:      matchInfo.GameID,
+				PlayerCount: len(matchInfo.Players),
+				MaxPlayers:  matchInfo.MaxPlayers,
+			}
+			return presence, nil
+		}
+	}
+
+	if away, err := database.Exists(presenceAwayKey(userID)); err == nil && away {
+		presence.State = PRESENCE_AWAY
+		return presence, nil
+	}
+
+	presence.State = PRESENCE_ONLINE
+	return presence, nil
+}
+
+// GetMany 여러 사용자의 접속 상태 (조회에 실패한 사용자는 제외)
+func (s *PresenceService) GetMany(userIDs []string) []dto.Presence {
+	presences := make([]dto.Presence, 0, len(userIDs))
+	for _, userID := range userIDs {
+		presence, err := s.Get(userID)
+		if err != nil {
+			log.Printf("Failed to load presence for user %s: %v", userID, err)
+			continue
+		}
+		presences = append(presences, *presence)
+	}
+	return presences
+}
+
+// SetAway 자리 비움 설정/해제 후 변경 발행
+func (s *PresenceService) SetAway(userID string, away bool) (*dto.Presence, error) {
+	var err error
+	if away {
+		err = database.Set(presenceAwayKey(userID), "1", SESSION_TTL)
+	} else {
+		err = database.Del(presenceAwayKey(userID))
+	}
+	if err != nil {
+		log.Printf("Failed to update away state for user %s: %v", userID, err)
+	}
+	return s.Refresh(userID)
+}
+
+// Refresh 현재 상태를 다시 계산해서 마지막으로 발행한 상태와 다르면 발행
+func (s *PresenceService) Refresh(userID string) (*dto.Presence, error) {
+	presence, err := s.Get(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 시각을 빼고 비교해서 같은 상태를 반복해서 알리지 않음
+	snapshot := *presence
+	snapshot.UpdatedAt = time.Time{}
+	snapshotJSON, _ := json.Marshal(snapshot)
+
+	lastKey := presenceLastKey(userID)
+	if last, err := database.Get(lastKey); err == nil && last == string(snapshotJSON) {
+		return presence, nil
+	}
+
+	if err := database.Set(lastKey, string(snapshotJSON), SESSION_TTL); err != nil {
+		log.Printf("Failed to store presence for user %s: %v", userID, err)
+	}
+	// 모든 기기에서 나가면 자리 비움도 해제
+	if presence.State == PRESENCE_OFFLINE {
+		database.Del(presenceAwayKey(userID))
+	}
+
+	presenceJSON, _ := json.Marshal(presence)
+	if err := database.Publish(PRESENCE_CHANNEL, string(presenceJSON)); err != nil {
+		log.Printf("Failed to publish presence for user %s: %v", userID, err)
+	}
+	return presence, nil
+}
+
+func presenceAwayKey(userID string) string {
+	return fmt.Sprintf("presence:away:%s", userID)
+}
+
+func presenceLastKey(userID string) string {
+	return fmt.Sprintf("presence:last:%s", userID)
+}
@@ -0,0 +1,174 @@
+package service
+
+import (
+	"encoding/json"
+	"game-server/internal/domain"
+	"game-server/internal/dto"
+	"game-server/internal/pkg/database"
+	"game-server/internal/pkg/errors"
+	"log"
+	"math"
+	"slices"
+)
+
+// 허용되는 리플레이 배속
+var REPLAY_SPEEDS = []float64{0.5, 1, 2, 4}
+
+// ReplayService 저장된 이벤트 로그로 게임 세션을 재구성
+// 이벤트 순서와 내용만으로 상태를 만들기 때문에 같은 로그는 항상 같은 결과를 냄
+type ReplayService struct{}
+
+func NewReplayService() *ReplayService {
+	return &ReplayService{}
+}
+
+// Replay 리플레이용으로 불러온 게임 세션 이벤트 로그
+type Replay struct {
+	GameID string
+	Events []domain.GameEvent
+}
+
+// LoadReplay 게임 세션의 전체 이벤트 로그를 eventId 순서로 로드
+func (s *ReplayService) LoadReplay(gameID string) (*Replay, error) {
+	if gameID == "" {
+		return nil, errors.InvalidInput()
+	}
+
+	replay := &Replay{GameID: gameID}
+	for event, err := range database.StreamEvents(database.GetEventStore(), gameID, database.EventQuery{
+		Limit: database.MAX_EVENT_PAGE_SIZE,
+	}) {
+		if err != nil {
+			log.Printf("Failed to load replay for game %s: %v", gameID, err)
+			return nil, errors.DBError()
+		}
+		replay.Events = append(replay.Events, event)
+	}
+
+	if len(replay.Events) == 0 {
+		return nil, errors.ReplayNotFound()
+	}
+	return replay, nil
+}
+
+// Duration 첫 이벤트부터 마지막 이벤트까지의 길이 (ms)
+func (r *Replay) Duration() int64 {
+	if len(r.Events) == 0 {
+		return 0
+	}
+	return r.Offset(len(r.Events) - 1)
+}
+
+// Offset i번째 이벤트의 시작 기준 위치 (ms)
+func (r *Replay) Offset(i int) int64 {
+	return r.Events[i].Timestamp - r.Events[0].Timestamp
+}
+
+// IndexAt 주어진 위치 이후 처음 재생할 이벤트 인덱스
+func (r *Replay) IndexAt(positionMs int64) int {
+	for i := range r.Events {
+		if r.Offset(i) > positionMs {
+			return i
+		}
+	}
+	return len(r.Events)
+}
+
+// StateAt 주어진 위치까지의 이벤트를 순서대로 적용한 상태 (적용 범위는 ApplyReplayEvent 참고)
+func (r *Replay) StateAt(positionMs int64) *dto.ReplayState {
+	state := &dto.ReplayState{
+		GameID:      r.GameID,
+		EventCounts: map[string]int{},
+	}
+	for i, event := range r.Events {
+		if r.Offset(i) > positionMs {
+			break
+		}
+		ApplyReplayEvent(state, event)
+		state.PositionMs = r.Offset(i)
+	}
+	if positionMs > state.PositionMs {
+		state.PositionMs = min(positionMs, r.Duration())
+	}
+	return state
+}
+
+// HasParticipant 게임에 참가한 사용자인지 확인 (시작 이벤트의 플레이어 또는 이벤트를 보낸 사용자)
+func (r *Replay) HasParticipant(userID string) bool {
+	if userID == "" {
+		return false
+	}
+	for _, event := range r.Events {
+		if event.UserID == userID {
+			return true
+		}
+		if event.Type != GAME_EVENT_STARTED {
+			continue
+		}
+		var gameInfo dto.GameInfo
+		if decodeEventData(event.Data, &gameInfo) != nil {
+			continue
+		}
+		for _, player := range gameInfo.Players {
+			if player.UserID == userID {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ToReplayEvent 이벤트를 리플레이 전송용 DTO로 변환
+func (r *Replay) ToReplayEvent(i int) *dto.ReplayEvent {
+	event := r.Events[i]
+	return &dto.ReplayEvent{
+		GameID:     r.GameID,
+		PositionMs: r.Offset(i),
+		EventID:    event.EventID,
+		Type:       event.Type,
+		UserID:     event.UserID,
+		Data:       event.Data,
+	}
+}
+
+// ApplyReplayEvent 이벤트 하나를 재구성 중인 상태에 적용
+// 서버가 기록한 게임 진행 이벤트만 Game 상태를 바꿈
+// 클라이언트 게임 이벤트(game_event로 받은 이벤트)의 data는 게임마다 형식이 달라 서버가 해석하지 않으므로
+// EventCounts와 LastEventID에만 반영되고, 내용은 replay_event로 받은 클라이언트가 직접 적용해야 함
+func ApplyReplayEvent(state *dto.ReplayState, event domain.GameEvent) {
+	state.AppliedEvents++
+	state.LastEventID = event.EventID
+	state.EventCounts[event.Type]++
+
+	switch event.Type {
+	case GAME_EVENT_STARTED, GAME_EVENT_ENDED:
+		var gameInfo dto.GameInfo
+		if decodeEventData(event.Data, &gameInfo) == nil {
+			state.Game = &gameInfo
+		}
+	case GAME_EVENT_PLAYER_DISCONNECTED:
+		var disconnected dto.PlayerDisconnectedResponse
+		if state.Game == nil || decodeEventData(event.Data, &disconnected) != nil {
+			return
+		}
+		for i := range state.Game.Players {
+			if state.Game.Players[i].UserID == disconnected.PlayerID {
+				state.Game.Players[i].Status = PLAYER_STATUS_DISCONNECTED
+			}
+		}
+	}
+}
+
+// ValidReplaySpeed 허용된 배속인지 확인
+func ValidReplaySpeed(speed float64) bool {
+	return !math.IsNaN(speed) && slices.Contains(REPLAY_SPEEDS, speed)
+}
+
+// decodeEventData 저장소에서 읽은 이벤트 데이터를 DTO로 변환
+func decodeEventData(data interface{}, target interface{}) error {
+	jsonData, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(jsonData, target)
+}
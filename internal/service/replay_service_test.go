@@ -0,0 +1,93 @@
+package service
+
+import (
+	"game-server/internal/domain"
+	"game-server/internal/dto"
+	"testing"
+)
+
+func testReplay() *Replay {
+	started := dto.GameInfo{
+		ID:     "game-1",
+		Status: GAME_STATUS_PLAYING,
+		Players: []dto.MatchPlayer{
+			{UserID: "a", Status: PLAYER_STATUS_PLAYING},
+			{UserID: "b", Status: PLAYER_STATUS_PLAYING},
+		},
+	}
+	return &Replay{
+		GameID: "game-1",
+		Events: []domain.GameEvent{
+			{EventID: "000000000001", Type: GAME_EVENT_STARTED, Data: started, Timestamp: 1000},
+			{EventID: "000000000002", Type: "move", UserID: "a", Data: map[string]interface{}{"x": 1}, Timestamp: 1500},
+			{EventID: "000000000003", Type: GAME_EVENT_PLAYER_DISCONNECTED, UserID: "b", Data: dto.PlayerDisconnectedResponse{PlayerID: "b"}, Timestamp: 2000},
+		},
+	}
+}
+
+func TestReplayStateAt(t *testing.T) {
+	replay := testReplay()
+
+	state := replay.StateAt(600)
+	if state.AppliedEvents != 2 || state.LastEventID != "000000000002" {
+		t.Fatalf("state at 600ms applied %d events (last %s)", state.AppliedEvents, state.LastEventID)
+	}
+	if state.PositionMs != 600 {
+		t.Fatalf("position = %d, want 600", state.PositionMs)
+	}
+	if state.Game == nil || state.Game.Players[1].Status != PLAYER_STATUS_PLAYING {
+		t.Fatalf("player b should still be playing at 600ms: %+v", state.Game)
+	}
+	if state.EventCounts["move"] != 1 {
+		t.Fatalf("client events should be counted, got %v", state.EventCounts)
+	}
+
+	final := replay.StateAt(replay.Duration())
+	if final.Game.Players[1].Status != PLAYER_STATUS_DISCONNECTED {
+		t.Fatal("player b should be disconnected at the end of the replay")
+	}
+
+	// 같은 로그는 항상 같은 결과
+	again := testReplay().StateAt(replay.Duration())
+	if again.AppliedEvents != final.AppliedEvents || again.Game.Players[1].Status != final.Game.Players[1].Status {
+		t.Fatal("replay is not deterministic")
+	}
+}
+
+func TestReplayIndexAt(t *testing.T) {
+	replay := testReplay()
+	cases := map[int64]int{-1: 0, 0: 1, 499: 1, 500: 2, 1000: 3}
+	for position, want := range cases {
+		if got := replay.IndexAt(position); got != want {
+			t.Errorf("IndexAt(%d) = %d, want %d", position, got, want)
+		}
+	}
+}
+
+func TestValidReplaySpeed(t *testing.T) {
+	for _, speed := range REPLAY_SPEEDS {
+		if !ValidReplaySpeed(speed) {
+			t.Errorf("speed %v should be allowed", speed)
+		}
+	}
+	for _, speed := range []float64{0, 3, -1} {
+		if ValidReplaySpeed(speed) {
+			t.Errorf("speed %v should be rejected", speed)
+		}
+	}
+}
+
+func TestReplayHasParticipant(t *testing.T) {
+	replay := testReplay()
+
+	for _, userID := range []string{"a", "b"} {
+		if !replay.HasParticipant(userID) {
+			t.Errorf("%s played the game and should be a participant", userID)
+		}
+	}
+	for _, userID := range []string{"c", ""} {
+		if replay.HasParticipant(userID) {
+			t.Errorf("%q should not be a participant", userID)
+		}
+	}
+}
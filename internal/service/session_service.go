@@ -0,0 +1,270 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"game-server/internal/dto"
+	"game-server/internal/pkg/database"
+	"game-server/internal/pkg/errors"
+	"log"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// 같은 사용자가 같은 종류의 기기로 다시 접속했을 때의 처리 정책
+	SESSION_POLICY_KICK_OLD   = "kick_old"   // 기존 세션에 session_replaced를 보내고 종료
+	SESSION_POLICY_REJECT_NEW = "reject_new" // 새 연결을 거절
+	SESSION_POLICY_MULTI      = "multi"      // 여러 세션 허용 (알림은 모든 세션으로 전송)
+
+	// SESSION_CHANNEL 기존 세션 교체를 모든 서버 노드에 알리는 Redis 채널
+	SESSION_CHANNEL = "socket:sessions"
+
+	// SESSION_TTL 세션 목록 보관 시간 (노드 장애로 정리되지 못한 세션이 영구히 남지 않도록 접속할 때마다 갱신)
+	SESSION_TTL = 24 * time.Hour
+
+	// 노드 생존 확인: 각 노드가 주기적으로 socket:node:<nodeId> 키를 갱신하고,
+	// 키가 만료된 노드(장애로 세션을 정리하지 못한 노드)의 세션은 없는 것으로 봄
+	NODE_HEARTBEAT_INTERVAL = 10 * time.Second
+	NODE_TTL                = 30 * time.Second
+)
+
+// 기기 종류
+const (
+	DEVICE_PC      = "pc"
+	DEVICE_CONSOLE = "console"
+	DEVICE_MOBILE  = "mobile" // 컴패니언 앱 (초대/채팅만 가능)
+)
+
+// 세션 기능 (알림을 보낼 세션을 고를 때 사용)
+const (
+	SESSION_CAPABILITY_PLAY = "can_play"
+	SESSION_CAPABILITY_CHAT = "can_chat"
+)
+
+var deviceCapabilities = map[string][]string{
+	DEVICE_PC:      {SESSION_CAPABILITY_PLAY, SESSION_CAPABILITY_CHAT},
+	DEVICE_CONSOLE: {SESSION_CAPABILITY_PLAY, SESSION_CAPABILITY_CHAT},
+	DEVICE_MOBILE:  {SESSION_CAPABILITY_CHAT},
+}
+
+// releaseSessionScript 세션 목록과 소켓 매핑에서 이 연결만 제거 (다른 연결의 세션은 건드리지 않음)
+// 반환값: 남은 세션 수
+var releaseSessionScript = redis.NewScript(`
+redis.call('HDEL', KEYS[1], ARGV[1])
+redis.call('HDEL', KEYS[2], ARGV[1])
+return redis.call('HLEN', KEYS[2])
+`)
+
+// SessionService 사용자별 소켓 세션 관리 (Redis)
+// socket:users(socketId -> userId), user:sessions:<userId>(socketId -> 세션 정보)
+type SessionService struct {
+	policy string
+	nodeID string // 이 노드의 세션을 표시 (노드 생존 확인에 사용)
+}
+
+func NewSessionService(policy string) *SessionService {
+	return &SessionService{policy: policy, nodeID: uuid.New().String()}
+}
+
+// storedSession Redis에 저장하는 세션 (클라이언트에는 노드 ID를 노출하지 않음)
+type storedSession struct {
+	dto.SessionInfo
+	NodeID string `json:"nodeId,omitempty"`
+}
+
+// Heartbeat 이 노드가 살아 있음을 표시 (NODE_HEARTBEAT_INTERVAL마다 호출)
+func (s *SessionService) Heartbeat() error {
+	return database.Set(nodeKey(s.nodeID), "1", NODE_TTL)
+}
+
+func (s *SessionService) Policy() string {
+	return s.policy
+}
+
+// DeviceCapabilities 기기 종류에 허용되는 기능 (기기 종류가 비어 있으면 pc)
+func (s *SessionService) DeviceCapabilities(device string) (string, []string, error) {
+	if device == "" {
+		device = DEVICE_PC
+	}
+	capabilities, ok := deviceCapabilities[device]
+	if !ok {
+		return "", nil, errors.BadRequestWithMessage(fmt.Sprintf("지원하지 않는 기기 종류입니다: %s", device))
+	}
+	return device, capabilities, nil
+}
+
+// Open 인증된 연결을 사용자 세션으로 등록
+// 정책은 같은 종류의 기기끼리만 적용 (PC와 모바일 앱은 동시에 접속 가능)
+// kick_old면 기존 세션 종료를 알리고, reject_new면 이미 세션이 있을 때 거절
+// Redis 장애 시에는 접속 자체를 막지 않도록 통과시킴
+func (s *SessionService) Open(userID string, session *dto.SessionInfo) error {
+	sessionsKey := userSessionsKey(userID)
+	sessionJSON, _ := json.Marshal(storedSession{SessionInfo: *session, NodeID: s.nodeID})
+	if err := database.HSet(sessionsKey, session.SocketID, string(sessionJSON)); err != nil {
+		log.Printf("Failed to store session for user %s: %v", userID, err)
+		return nil
+	}
+	database.Expire(sessionsKey, SESSION_TTL)
+
+	// 동시에 접속한 경우 둘 다 거절될 수는 있어도 둘 다 허용되지는 않음
+	// 죽은 노드에 남은 세션은 Sessions에서 제외되므로 정책 판단에 쓰이지 않음
+	if s.policy == SESSION_POLICY_REJECT_NEW {
+		sessions, err := s.Sessions(userID)
+		if err == nil && countDevice(sessions, session.Device) > 1 {
+			database.HDel(sessionsKey, session.SocketID)
+			return errors.SessionAlreadyActive()
+		}
+	}
+
+	if err := database.HSet("socket:users", session.SocketID, userID); err != nil {
+		log.Printf("Failed to store socket mapping: %v", err)
+	}
+
+	if s.policy == SESSION_POLICY_KICK_OLD {
+		replacedJSON, _ := json.Marshal(dto.SessionReplaced{UserID: userID, Device: session.Device, SocketID: session.SocketID})
+		if err := database.Publish(SESSION_CHANNEL, string(replacedJSON)); err != nil {
+			log.Printf("Failed to publish session replacement for user %s: %v", userID, err)
+		}
+	}
+
+	return nil
+}
+
+// Close 연결의 세션 해제 후 사용자에게 남은 (살아 있는 노드의) 세션 수 반환
+func (s *SessionService) Close(userID, socketID string) (int64, error) {
+	if err := s.release(userID, socketID); err != nil {
+		return 0, err
+	}
+	sessions, err := s.Sessions(userID)
+	if err != nil {
+		return 0, err
+	}
+	return int64(len(sessions)), nil
+}
+
+func (s *SessionService) release(userID, socketID string) error {
+	keys := []string{"socket:users", userSessionsKey(userID)}
+	return database.RunScript(releaseSessionScript, keys, socketID).Err()
+}
+
+// Sessions 사용자의 모든 세션 (다른 노드의 세션 포함, 접속 순서대로)
+// 하트비트가 끊긴 노드의 세션은 제외하고 정리함
+func (s *SessionService) Sessions(userID string) ([]dto.SessionInfo, error) {
+	values, err := database.HGetAll(userSessionsKey(userID))
+	if err != nil {
+		return nil, err
+	}
+
+	alive := make(map[string]bool)
+	sessions := make([]dto.SessionInfo, 0, len(values))
+	for socketID, value := range values {
+		var session storedSession
+		if err := json.Unmarshal([]byte(value), &session); err != nil {
+			log.Printf("Invalid session %s for user %s: %v", socketID, userID, err)
+			continue
+		}
+		if !s.nodeAlive(session.NodeID, alive) {
+			log.Printf("Dropping stale session %s for user %s: node %s is gone", socketID, userID, session.NodeID)
+			if err := s.release(userID, socketID); err != nil {
+				log.Printf("Failed to release stale session %s: %v", socketID, err)
+			}
+			continue
+		}
+		sessions = append(sessions, session.SessionInfo)
+	}
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].ConnectedAt.Before(sessions[j].ConnectedAt)
+	})
+	return sessions, nil
+}
+
+// IsOnline 세션이 하나라도 있는지 확인
+func (s *SessionService) IsOnline(userID string) (bool, error) {
+	sessions, err := s.Sessions(userID)
+	if err != nil {
+		return false, err
+	}
+	return len(sessions) > 0, nil
+}
+
+// nodeAlive 노드 하트비트 키가 남아 있는지 확인 (한 번 조회한 노드는 checked에 기록)
+// 이 노드와 노드 ID가 없는 세션(이전 버전 노드가 저장)은 항상 살아 있는 것으로 봄 (후자는 SESSION_TTL까지 유지)
+// Redis 조회에 실패하면 세션을 지우지 않도록 살아 있는 것으로 봄
+func (s *SessionService) nodeAlive(nodeID string, checked map[string]bool) bool {
+	if nodeID == "" || nodeID == s.nodeID {
+		return true
+	}
+	if alive, ok := checked[nodeID]; ok {
+		return alive
+	}
+	alive, err := database.Exists(nodeKey(nodeID))
+	if err != nil {
+		alive = true
+	}
+	checked[nodeID] = alive
+	return alive
+}
+
+// HasCapability 기능이 있는 세션이 하나라도 있는지 확인
+func (s *SessionService) HasCapability(userID, capability string) (bool, error) {
+	sessions, err := s.Sessions(userID)
+	if err != nil {
+		return false, err
+	}
+	for _, session := range sessions {
+		if slices.Contains(session.Capabilities, capability) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// Presence 사용자의 모든 세션을 합친 접속 상태 (기기 종류와 기능의 합집합)
+func (s *SessionService) Presence(userID string) (*dto.SessionPresence, error) {
+	sessions, err := s.Sessions(userID)
+	if err != nil {
+		return nil, errors.DBError()
+	}
+
+	presence := &dto.SessionPresence{
+		UserID:       userID,
+		Online:       len(sessions) > 0,
+		Devices:      []string{},
+		Capabilities: []string{},
+		Sessions:     sessions,
+	}
+	for _, session := range sessions {
+		if !slices.Contains(presence.Devices, session.Device) {
+			presence.Devices = append(presence.Devices, session.Device)
+		}
+		for _, capability := range session.Capabilities {
+			if !slices.Contains(presence.Capabilities, capability) {
+				presence.Capabilities = append(presence.Capabilities, capability)
+			}
+		}
+	}
+	return presence, nil
+}
+
+func countDevice(sessions []dto.SessionInfo, device string) int {
+	count := 0
+	for _, session := range sessions {
+		if session.Device == device {
+			count++
+		}
+	}
+	return count
+}
+
+func userSessionsKey(userID string) string {
+	return fmt.Sprintf("user:sessions:%s", userID)
+}
+
+func nodeKey(nodeID string) string {
+	return fmt.Sprintf("socket:node:%s", nodeID)
+}
@@ -0,0 +1,108 @@
+package service
+
+import (
+	"game-server/internal/dto"
+	"game-server/internal/pkg/database/redistest"
+	"testing"
+	"time"
+)
+
+func openSession(t *testing.T, s *SessionService, userID, socketID, device string) error {
+	t.Helper()
+	_, capabilities, err := s.DeviceCapabilities(device)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s.Open(userID, &dto.SessionInfo{
+		SocketID:     socketID,
+		Device:       device,
+		Capabilities: capabilities,
+		ConnectedAt:  time.Now(),
+	})
+}
+
+func TestSessionsIgnoreDeadNodes(t *testing.T) {
+	redis := redistest.Start(t)
+	crashed := NewSessionService(SESSION_POLICY_MULTI)
+	live := NewSessionService(SESSION_POLICY_MULTI)
+	crashed.Heartbeat()
+	live.Heartbeat()
+
+	if err := openSession(t, crashed, "user-1", "socket-a", DEVICE_PC); err != nil {
+		t.Fatal(err)
+	}
+	if err := openSession(t, crashed, "user-1", "socket-b", DEVICE_MOBILE); err != nil {
+		t.Fatal(err)
+	}
+	if sessions, _ := live.Sessions("user-1"); len(sessions) != 2 {
+		t.Fatalf("sessions on a live node = %d, want 2", len(sessions))
+	}
+
+	// crashed 노드는 하트비트를 멈추고, live 노드만 계속 갱신
+	redis.FastForward(NODE_TTL / 2)
+	live.Heartbeat()
+	redis.FastForward(NODE_TTL/2 + time.Second)
+
+	sessions, err := live.Sessions("user-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sessions) != 0 {
+		t.Fatalf("sessions of a dead node should be ignored, got %+v", sessions)
+	}
+	if online, _ := live.IsOnline("user-1"); online {
+		t.Fatal("user with only dead sessions should be offline")
+	}
+	// 죽은 세션은 정리됨
+	if redis.Exists("user:sessions:user-1") || redis.HGet("socket:users", "socket-a") != "" {
+		t.Fatal("stale session entries should be removed")
+	}
+}
+
+func TestRejectNewIgnoresDeadSessions(t *testing.T) {
+	redis := redistest.Start(t)
+	crashed := NewSessionService(SESSION_POLICY_REJECT_NEW)
+	live := NewSessionService(SESSION_POLICY_REJECT_NEW)
+	crashed.Heartbeat()
+
+	if err := openSession(t, crashed, "user-1", "socket-old", DEVICE_PC); err != nil {
+		t.Fatal(err)
+	}
+	if err := openSession(t, live, "user-1", "socket-new", DEVICE_PC); err == nil {
+		t.Fatal("second pc session should be rejected while the first node is alive")
+	}
+
+	redis.FastForward(NODE_TTL + time.Second)
+	if err := openSession(t, live, "user-1", "socket-new", DEVICE_PC); err != nil {
+		t.Fatalf("session on a dead node should not lock the user out: %v", err)
+	}
+}
+
+func TestCloseCountsOnlyLiveSessions(t *testing.T) {
+	redis := redistest.Start(t)
+	crashed := NewSessionService(SESSION_POLICY_MULTI)
+	live := NewSessionService(SESSION_POLICY_MULTI)
+	crashed.Heartbeat()
+
+	openSession(t, crashed, "user-1", "socket-a", DEVICE_PC)
+	openSession(t, crashed, "user-1", "socket-b", DEVICE_PC)
+	openSession(t, live, "user-1", "socket-c", DEVICE_PC)
+	openSession(t, live, "user-1", "socket-d", DEVICE_MOBILE)
+
+	redis.FastForward(NODE_TTL + time.Second)
+
+	remaining, err := live.Close("user-1", "socket-c")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if remaining != 1 {
+		t.Fatalf("remaining = %d, want 1 (only the live mobile session)", remaining)
+	}
+	if canPlay, _ := live.HasCapability("user-1", SESSION_CAPABILITY_PLAY); canPlay {
+		t.Fatal("dead pc sessions should not count as playable")
+	}
+
+	if remaining, _ := live.Close("user-1", "socket-d"); remaining != 0 {
+		t.Fatalf("remaining = %d after the last live session closed", remaining)
+	}
+}
@@ -0,0 +1,82 @@
+package socket
+
+import (
+	"expvar"
+	"game-server/internal/pkg/errors"
+	"log"
+	"time"
+)
+
+const (
+	// PROXY 헤더를 기다리는 최대 시간
+	PROXY_HEADER_TIMEOUT = 5 * time.Second
+)
+
+var (
+	activeConnections   = expvar.NewInt("socket_connections_active")
+	rejectedConnections = expvar.NewMap("socket_connections_rejected_total")
+)
+
+// admitConn 전체 동시 연결 수 제한을 확인하고 연결 등록
+// PROXY 프로토콜을 쓰면 연결 주소는 로드밸런서이므로 IP별 제한은 헤더를 읽은 뒤 admitIP로 확인
+func (s *MatchServer) admitConn(client *Client) *errors.AppError {
+	s.connsMux.Lock()
+	defer s.connsMux.Unlock()
+
+	if s.maxConnections > 0 && len(s.conns) >= s.maxConnections {
+		rejectedConnections.Add("max_connections", 1)
+		return errors.ServerBusy()
+	}
+	if !s.proxyProtocol {
+		if err := s.admitIPLocked(client, client.IP); err != nil {
+			return err
+		}
+	}
+
+	s.conns[client] = struct{}{}
+	activeConnections.Add(1)
+	return nil
+}
+
+// admitIP PROXY 헤더로 확인한 실제 클라이언트 IP의 연결 수 제한을 확인하고 등록
+func (s *MatchServer) admitIP(client *Client, ip string) *errors.AppError {
+	s.connsMux.Lock()
+	defer s.connsMux.Unlock()
+	return s.admitIPLocked(client, ip)
+}
+
+func (s *MatchServer) admitIPLocked(client *Client, ip string) *errors.AppError {
+	client.IP = ip
+	if s.maxConnectionsPerIP > 0 && s.connsPerIP[ip] >= s.maxConnectionsPerIP {
+		rejectedConnections.Add("max_connections_per_ip", 1)
+		return errors.TooManyConnections()
+	}
+	client.countedIP = ip
+	s.connsPerIP[ip]++
+	return nil
+}
+
+// rejectConn 제한에 걸린 연결에 에러 전송
+// TLS 핸드셰이크 전이라 평문 에러 프레임을 보낼 수 없으면 바로 닫음
+func (s *MatchServer) rejectConn(client *Client, clientAddr string, err *errors.AppError) {
+	log.Printf("Rejected connection from %s (ip %s): %s", clientAddr, client.IP, err.Code)
+	if s.tlsConfig == nil {
+		s.sendErrorToClient(client, nil, err)
+	}
+}
+
+func (s *MatchServer) releaseConn(client *Client) {
+	s.connsMux.Lock()
+	defer s.connsMux.Unlock()
+
+	if _, ok := s.conns[client]; !ok {
+		return
+	}
+	delete(s.conns, client)
+	if ip := client.countedIP; ip != "" {
+		if s.connsPerIP[ip]--; s.connsPerIP[ip] <= 0 {
+			delete(s.connsPerIP, ip)
+		}
+	}
+	activeConnections.Add(-1)
+}
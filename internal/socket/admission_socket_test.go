@@ -0,0 +1,141 @@
+package socket
+
+import (
+	"encoding/json"
+	"game-server/internal/dto"
+	"net"
+	"testing"
+	"time"
+)
+
+func newAdmissionServer(maxConnections int, maxPerIP int) *MatchServer {
+	return &MatchServer{
+		conns:               make(map[*Client]struct{}),
+		connsPerIP:          make(map[string]int),
+		maxConnections:      maxConnections,
+		maxConnectionsPerIP: maxPerIP,
+	}
+}
+
+func TestAdmitConnLimits(t *testing.T) {
+	s := newAdmissionServer(3, 2)
+	a := &Client{IP: "10.0.0.1"}
+	b := &Client{IP: "10.0.0.1"}
+	c := &Client{IP: "10.0.0.1"}
+	d := &Client{IP: "10.0.0.2"}
+	e := &Client{IP: "10.0.0.3"}
+
+	if err := s.admitConn(a); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.admitConn(b); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.admitConn(c); err == nil || err.Code != "TOO_MANY_CONNECTIONS" {
+		t.Fatalf("third connection from one IP: err = %v", err)
+	}
+	if err := s.admitConn(d); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.admitConn(e); err == nil || err.Code != "SERVER_BUSY" {
+		t.Fatalf("connection over the server limit: err = %v", err)
+	}
+
+	s.releaseConn(a)
+	s.releaseConn(a) // 두 번 해제해도 한 번만 반영
+	if s.connsPerIP["10.0.0.1"] != 1 || len(s.conns) != 2 {
+		t.Fatalf("after release: perIP = %v, conns = %d", s.connsPerIP, len(s.conns))
+	}
+}
+
+func TestAdmitIPAfterProxyHeader(t *testing.T) {
+	s := newAdmissionServer(0, 1)
+	s.proxyProtocol = true
+
+	// 같은 로드밸런서 주소로 들어온 연결은 헤더를 읽기 전까지 IP별 제한에 포함되지 않음
+	first := &Client{IP: "10.0.0.254"}
+	second := &Client{IP: "10.0.0.254"}
+	if err := s.admitConn(first); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.admitConn(second); err != nil {
+		t.Fatalf("second connection through the load balancer: %v", err)
+	}
+	if len(s.connsPerIP) != 0 {
+		t.Fatalf("load balancer IP should not be counted: %v", s.connsPerIP)
+	}
+
+	// 헤더의 실제 클라이언트 IP로 제한 확인
+	if err := s.admitIP(first, "203.0.113.7"); err != nil {
+		t.Fatal(err)
+	}
+	if first.IP != "203.0.113.7" || s.connsPerIP["203.0.113.7"] != 1 {
+		t.Fatalf("client ip = %s, perIP = %v", first.IP, s.connsPerIP)
+	}
+	if err := s.admitIP(second, "203.0.113.7"); err == nil || err.Code != "TOO_MANY_CONNECTIONS" {
+		t.Fatalf("second connection from one real IP: err = %v", err)
+	}
+
+	s.releaseConn(second)
+	s.releaseConn(first)
+	if len(s.connsPerIP) != 0 || len(s.conns) != 0 {
+		t.Fatalf("after release: perIP = %v, conns = %d", s.connsPerIP, len(s.conns))
+	}
+}
+
+func TestPendingProxyHeaderCountsAgainstServerLimit(t *testing.T) {
+	s := newAdmissionServer(1, 1)
+	s.proxyProtocol = true
+
+	// PROXY 헤더를 아직 보내지 않은 연결
+	stalled, stalledPeer := net.Pipe()
+	defer stalledPeer.Close()
+	s.connWG.Add(1)
+	go s.handleConnection(stalled)
+
+	waitFor(t, func() bool {
+		s.connsMux.Lock()
+		defer s.connsMux.Unlock()
+		return len(s.conns) == 1
+	})
+
+	second, secondPeer := net.Pipe()
+	defer secondPeer.Close()
+	s.connWG.Add(1)
+	go s.handleConnection(second)
+
+	secondPeer.SetReadDeadline(time.Now().Add(time.Second))
+	buffer := make([]byte, 4096)
+	n, err := secondPeer.Read(buffer)
+	if err != nil {
+		t.Fatalf("expected a rejection frame: %v", err)
+	}
+	var frame struct {
+		Type string            `json:"type"`
+		Data dto.ErrorResponse `json:"data"`
+	}
+	if err := json.Unmarshal(buffer[:n], &frame); err != nil {
+		t.Fatal(err)
+	}
+	if frame.Data.Code != "SERVER_BUSY" {
+		t.Fatalf("frame = %+v", frame)
+	}
+
+	stalledPeer.Close()
+	s.connWG.Wait()
+	if len(s.conns) != 0 || len(s.connsPerIP) != 0 {
+		t.Fatalf("connections should be released: conns = %d, perIP = %v", len(s.conns), s.connsPerIP)
+	}
+}
+
+// waitFor 조건이 참이 될 때까지 최대 1초 대기
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("condition not met within 1s")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
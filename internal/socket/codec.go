@@ -0,0 +1,281 @@
+package socket
+
+import (
+	"bufio"
+	"bytes"
+	"compress/flate"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+const (
+	// 바이너리 프레임 하나의 최대 크기 (4바이트 길이 헤더 제외)
+	MAX_FRAME_SIZE = 1 << 20
+)
+
+// Codec 소켓 메시지 인코딩 방식
+// DTO의 json 태그를 그대로 스키마로 사용하므로 JSON과 MessagePack이 같은 필드 이름을 가짐
+type Codec interface {
+	Name() string
+	Marshal(v interface{}) ([]byte, error)
+	Unmarshal(data []byte, v interface{}) error
+	// DecodeMessage 봉투(id/type)만 해석하고 data는 원본 바이트로 남겨둠
+	DecodeMessage(data []byte) (*SocketMessage, error)
+}
+
+// rawPayload 아직 해석하지 않은 메시지 data (요청 DTO로 한 번에 디코딩)
+type rawPayload struct {
+	codec Codec
+	data  []byte
+}
+
+var (
+	JSONCodec    Codec = jsonCodec{}
+	MsgpackCodec Codec = msgpackCodec{}
+)
+
+// DecodePayload 메시지 data를 요청 DTO로 디코딩
+// 수신 메시지는 원본 바이트에서 바로 디코딩하고, 그 외 값은 JSON으로 한 번 변환
+func DecodePayload(data interface{}, target interface{}) error {
+	if raw, ok := data.(rawPayload); ok {
+		if len(raw.data) == 0 {
+			return nil
+		}
+		return raw.codec.Unmarshal(raw.data, target)
+	}
+
+	jsonData, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(jsonData, target)
+}
+
+func codecByName(name string) Codec {
+	if inner, ok := strings.CutSuffix(name, deflateSuffix); ok {
+		if codec := codecByName(inner); codec != nil {
+			return compressed(codec)
+		}
+		return nil
+	}
+
+	switch name {
+	case JSONCodec.Name():
+		return JSONCodec
+	case MsgpackCodec.Name():
+		return MsgpackCodec
+	default:
+		return nil
+	}
+}
+
+// ========== 연결별 인코딩 ==========
+
+// currentCodec 이 연결로 보낼 메시지의 인코딩 (여러 고루틴에서 호출)
+func (c *Client) currentCodec() Codec {
+	c.codecMux.RLock()
+	defer c.codecMux.RUnlock()
+	return c.codec
+}
+
+// switchCodec auth_success를 보낸 뒤 협상된 인코딩으로 전환 (읽기 루프에서 호출)
+// 보내는 메시지는 바로 전환하고, 받는 메시지는 이미 버퍼에 들어온 데이터를 기존 인코딩으로 모두 읽은 뒤 전환
+// 클라이언트는 auth_success를 받은 뒤에만 새 인코딩으로 보내므로 그 전에 도착한 데이터는 JSON
+func (c *Client) switchCodec(codec Codec) {
+	c.codecMux.Lock()
+	c.codec = codec
+	c.codecMux.Unlock()
+	c.nextCodec = codec
+}
+
+// currentReadCodec 다음 메시지를 읽을 인코딩 (읽기 루프에서만 호출)
+func (c *Client) currentReadCodec(reader *bufio.Reader) Codec {
+	if c.nextCodec != nil && reader.Buffered() == 0 {
+		c.readCodec = c.nextCodec
+		c.nextCodec = nil
+	}
+	return c.readCodec
+}
+
+// ========== JSON ==========
+
+type jsonCodec struct{}
+
+func (jsonCodec) Name() string {
+	return "json"
+}
+
+func (jsonCodec) Marshal(v interface{}) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+func (jsonCodec) Unmarshal(data []byte, v interface{}) error {
+	return json.Unmarshal(data, v)
+}
+
+func (c jsonCodec) DecodeMessage(data []byte) (*SocketMessage, error) {
+	var frame struct {
+		ID   string          `json:"id"`
+		Type string          `json:"type"`
+		Data json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(data, &frame); err != nil {
+		return nil, err
+	}
+	return &SocketMessage{
+		ID:   frame.ID,
+		Type: frame.Type,
+		Data: rawPayload{codec: c, data: frame.Data},
+	}, nil
+}
+
+// ========== MessagePack ==========
+
+type msgpackCodec struct{}
+
+func (msgpackCodec) Name() string {
+	return "msgpack"
+}
+
+func (msgpackCodec) Marshal(v interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := msgpack.NewEncoder(&buf)
+	enc.SetCustomStructTag("json")
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (msgpackCodec) Unmarshal(data []byte, v interface{}) error {
+	dec := msgpack.NewDecoder(bytes.NewReader(data))
+	dec.SetCustomStructTag("json")
+	return dec.Decode(v)
+}
+
+func (c msgpackCodec) DecodeMessage(data []byte) (*SocketMessage, error) {
+	var frame struct {
+		ID   string             `json:"id"`
+		Type string             `json:"type"`
+		Data msgpack.RawMessage `json:"data"`
+	}
+	if err := c.Unmarshal(data, &frame); err != nil {
+		return nil, err
+	}
+	return &SocketMessage{
+		ID:   frame.ID,
+		Type: frame.Type,
+		Data: rawPayload{codec: c, data: frame.Data},
+	}, nil
+}
+
+// ========== 압축 ==========
+
+const deflateSuffix = "+deflate"
+
+// deflateCodec 다른 인코딩의 결과를 메시지 단위로 DEFLATE 압축
+// JSONCodec이 아니므로 송수신 모두 길이 헤더가 붙은 프레임 단위 (헤더의 길이는 압축된 크기)
+type deflateCodec struct {
+	inner Codec
+}
+
+var flateWriters = sync.Pool{
+	New: func() interface{} {
+		w, _ := flate.NewWriter(nil, flate.DefaultCompression)
+		return w
+	},
+}
+
+// compressed codec으로 인코딩한 뒤 압축하는 인코딩
+func compressed(codec Codec) Codec {
+	return deflateCodec{inner: codec}
+}
+
+func (c deflateCodec) Name() string {
+	return c.inner.Name() + deflateSuffix
+}
+
+func (c deflateCodec) Marshal(v interface{}) ([]byte, error) {
+	data, err := c.inner.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	w := flateWriters.Get().(*flate.Writer)
+	defer flateWriters.Put(w)
+	w.Reset(&buf)
+	if _, err := w.Write(data); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (c deflateCodec) Unmarshal(data []byte, v interface{}) error {
+	data, err := inflate(data)
+	if err != nil {
+		return err
+	}
+	return c.inner.Unmarshal(data, v)
+}
+
+func (c deflateCodec) DecodeMessage(data []byte) (*SocketMessage, error) {
+	data, err := inflate(data)
+	if err != nil {
+		return nil, err
+	}
+	return c.inner.DecodeMessage(data)
+}
+
+// inflate 압축 해제 (풀린 크기도 프레임 최대 크기로 제한)
+func inflate(data []byte) ([]byte, error) {
+	r := flate.NewReader(bytes.NewReader(data))
+	defer r.Close()
+
+	inflated, err := io.ReadAll(io.LimitReader(r, MAX_FRAME_SIZE+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(inflated) > MAX_FRAME_SIZE {
+		return nil, fmt.Errorf("inflated frame too large")
+	}
+	return inflated, nil
+}
+
+// ========== 프레임 ==========
+
+// readFrame 4바이트 빅엔디언 길이 헤더가 붙은 바이너리 프레임 읽기
+func readFrame(r io.Reader) ([]byte, error) {
+	var header [4]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return nil, err
+	}
+
+	size := binary.BigEndian.Uint32(header[:])
+	if size > MAX_FRAME_SIZE {
+		return nil, fmt.Errorf("frame too large: %d bytes", size)
+	}
+
+	frame := make([]byte, size)
+	if _, err := io.ReadFull(r, frame); err != nil {
+		return nil, err
+	}
+	return frame, nil
+}
+
+// appendFrame 길이 헤더를 붙인 바이너리 프레임 생성 (한 번의 Write로 전송하기 위함)
+func appendFrame(payload []byte) []byte {
+	frame := make([]byte, 4+len(payload))
+	binary.BigEndian.PutUint32(frame, uint32(len(payload)))
+	copy(frame[4:], payload)
+	return frame
+}
@@ -0,0 +1,362 @@
+package socket
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"game-server/internal/dto"
+	"io"
+	"testing"
+	"time"
+)
+
+type codecSample struct {
+	name    string
+	request interface{}        // 수신 메시지 data
+	target  func() interface{} // 수신 메시지를 디코딩할 DTO
+	reply   interface{}        // 송신 메시지 data
+}
+
+func codecSamples() []codecSample {
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	players := []dto.MatchPlayer{
+		{UserID: "user-1", Status: "joined", JoinedAt: &now},
+		{UserID: "user-2", Status: "joined", JoinedAt: &now},
+		{UserID: "user-3", Status: "joined", JoinedAt: &now},
+		{UserID: "user-4", Status: "joined", JoinedAt: &now},
+	}
+	teams := []dto.Team{
+		{ID: 1, Players: []string{"user-1", "user-3"}},
+		{ID: 2, Players: []string{"user-2", "user-4"}},
+	}
+
+	return []codecSample{
+		{
+			name:    "create_match",
+			request: dto.CreateMatchRequest{GameID: "battle-arena", MaxPlayers: 4},
+			target:  func() interface{} { return &dto.CreateMatchRequest{} },
+			reply: dto.CreateMatchResponse{
+				MatchID:    "7f1c2d9e-5a44-4c1e-9a0e-1f6f0c6b2a11",
+				GameID:     "battle-arena",
+				HostID:     "user-1",
+				MaxPlayers: 4,
+				Message:    "Match created successfully",
+			},
+		},
+		{
+			name:    "invite_friends",
+			request: dto.InviteFriendRequest{MatchID: "7f1c2d9e-5a44-4c1e-9a0e-1f6f0c6b2a11", FriendIds: []string{"user-2", "user-3", "user-4"}},
+			target:  func() interface{} { return &dto.InviteFriendRequest{} },
+			reply: dto.InviteFriendResponse{
+				MatchID:    "7f1c2d9e-5a44-4c1e-9a0e-1f6f0c6b2a11",
+				InvitedIds: []string{"user-2", "user-3"},
+				FailedIds:  []string{"user-4"},
+				Message:    "Invited 2 friends, 1 failed",
+			},
+		},
+		{
+			name:    "start_match",
+			request: dto.StartMatchRequest{MatchID: "7f1c2d9e-5a44-4c1e-9a0e-1f6f0c6b2a11"},
+			target:  func() interface{} { return &dto.StartMatchRequest{} },
+			reply: dto.GameInfo{
+				ID:          "0b5e3f64-2a7d-4f0e-8d59-3c1a9b7e6d42",
+				Status:      "playing",
+				PlayerCount: len(players),
+				Teams:       teams,
+				Players:     players,
+				CreatedAt:   now,
+				StartedAt:   &now,
+				Metadata:    map[string]interface{}{"matchId": "7f1c2d9e-5a44-4c1e-9a0e-1f6f0c6b2a11", "gameId": "battle-arena"},
+			},
+		},
+		{
+			name: "game_event",
+			request: dto.GameEventRequest{
+				Type: "player_move",
+				Data: map[string]interface{}{"x": 12.5, "y": -3.25, "z": 0.0, "tick": 10231},
+			},
+			target: func() interface{} { return &dto.GameEventRequest{} },
+			reply:  dto.SuccessResponse{Message: "ok"},
+		},
+	}
+}
+
+var codecs = []Codec{JSONCodec, MsgpackCodec, compressed(JSONCodec), compressed(MsgpackCodec)}
+
+// sameJSON 숫자 타입 차이(int/float64)를 무시하고 JSON 표현으로 비교
+func sameJSON(t *testing.T, want, got interface{}) bool {
+	t.Helper()
+	wantJSON, err := json.Marshal(want)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gotJSON, err := json.Marshal(got)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return bytes.Equal(wantJSON, gotJSON)
+}
+
+func TestCodecRequestRoundTrip(t *testing.T) {
+	for _, codec := range codecs {
+		for _, sample := range codecSamples() {
+			encoded, err := codec.Marshal(SocketMessage{ID: "req-42", Type: sample.name, Data: sample.request})
+			if err != nil {
+				t.Fatalf("%s/%s: %v", codec.Name(), sample.name, err)
+			}
+
+			msg, err := codec.DecodeMessage(encoded)
+			if err != nil {
+				t.Fatalf("%s/%s: %v", codec.Name(), sample.name, err)
+			}
+			if msg.ID != "req-42" || msg.Type != sample.name {
+				t.Fatalf("%s/%s: envelope = %q/%q", codec.Name(), sample.name, msg.ID, msg.Type)
+			}
+
+			target := sample.target()
+			if err := DecodePayload(msg.Data, target); err != nil {
+				t.Fatalf("%s/%s: %v", codec.Name(), sample.name, err)
+			}
+			if !sameJSON(t, sample.request, target) {
+				t.Errorf("%s/%s: decoded %+v, want %+v", codec.Name(), sample.name, target, sample.request)
+			}
+		}
+	}
+}
+
+func TestCodecReplyRoundTrip(t *testing.T) {
+	for _, codec := range codecs {
+		for _, sample := range codecSamples() {
+			msg := SocketMessage{ID: "req-42", Kind: MESSAGE_KIND_REPLY, Type: sample.name, Data: sample.reply}
+			encoded, err := codec.Marshal(msg)
+			if err != nil {
+				t.Fatalf("%s/%s: %v", codec.Name(), sample.name, err)
+			}
+
+			var decoded map[string]interface{}
+			if err := codec.Unmarshal(encoded, &decoded); err != nil {
+				t.Fatalf("%s/%s: %v", codec.Name(), sample.name, err)
+			}
+			if decoded["kind"] != MESSAGE_KIND_REPLY || decoded["type"] != sample.name {
+				t.Errorf("%s/%s: envelope = %v", codec.Name(), sample.name, decoded)
+			}
+
+			// json 태그를 스키마로 쓰므로 두 인코딩의 필드 이름이 같아야 함
+			var want map[string]interface{}
+			wantJSON, _ := json.Marshal(msg)
+			json.Unmarshal(wantJSON, &want)
+			if !sameJSON(t, want["data"], decoded["data"]) {
+				t.Errorf("%s/%s: data = %v, want %v", codec.Name(), sample.name, decoded["data"], want["data"])
+			}
+		}
+	}
+}
+
+func TestDecodePayload(t *testing.T) {
+	// data가 없는 메시지는 DTO를 그대로 둠
+	req := dto.CreateMatchRequest{GameID: "keep"}
+	if err := DecodePayload(rawPayload{codec: JSONCodec}, &req); err != nil || req.GameID != "keep" {
+		t.Fatalf("empty payload: %v, %+v", err, req)
+	}
+
+	// 수신 메시지가 아닌 값은 JSON으로 변환해서 디코딩
+	if err := DecodePayload(map[string]interface{}{"gameId": "battle-arena", "maxPlayers": 4}, &req); err != nil {
+		t.Fatal(err)
+	}
+	if req.GameID != "battle-arena" || req.MaxPlayers != 4 {
+		t.Fatalf("decoded %+v", req)
+	}
+
+	// 타입이 맞지 않으면 에러
+	msg, err := JSONCodec.DecodeMessage([]byte(`{"type":"create_match","data":{"maxPlayers":"four"}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := DecodePayload(msg.Data, &dto.CreateMatchRequest{}); err == nil {
+		t.Fatal("mistyped field should fail to decode")
+	}
+}
+
+func TestCompressedCodec(t *testing.T) {
+	for _, codec := range []Codec{JSONCodec, MsgpackCodec} {
+		deflate := compressed(codec)
+		if codecByName(deflate.Name()) != deflate {
+			t.Fatalf("codecByName(%q) should return the compressed codec", deflate.Name())
+		}
+
+		// 반복이 많은 메시지는 압축 후 더 작아짐
+		reply := SocketMessage{Type: "friends_list", Data: bytes.Repeat([]byte("user-1234,"), 200)}
+		plain, _ := codec.Marshal(reply)
+		packed, err := deflate.Marshal(reply)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(packed) >= len(plain) {
+			t.Errorf("%s: compressed %d bytes, plain %d bytes", deflate.Name(), len(packed), len(plain))
+		}
+
+		// 저장된 응답은 재접속 후 다른 인코딩으로도 변환 가능
+		converted, err := cachedReply(codec, deflate.Name()+":"+string(packed))
+		if err != nil {
+			t.Fatalf("%s: cached reply conversion: %v", deflate.Name(), err)
+		}
+		var got, want interface{}
+		codec.Unmarshal(converted, &got)
+		codec.Unmarshal(plain, &want)
+		if !sameJSON(t, want, got) {
+			t.Fatalf("%s: converted reply = %v, want %v", deflate.Name(), got, want)
+		}
+	}
+
+	if _, err := compressed(JSONCodec).DecodeMessage([]byte("not deflate")); err == nil {
+		t.Fatal("invalid compressed data should fail to decode")
+	}
+}
+
+func TestFrames(t *testing.T) {
+	payload := []byte("hello")
+	frame, err := readFrame(bytes.NewReader(appendFrame(payload)))
+	if err != nil || !bytes.Equal(frame, payload) {
+		t.Fatalf("readFrame = %q, %v", frame, err)
+	}
+
+	var header [4]byte
+	binary.BigEndian.PutUint32(header[:], MAX_FRAME_SIZE+1)
+	if _, err := readFrame(bytes.NewReader(header[:])); err == nil {
+		t.Fatal("oversized frame should be rejected")
+	}
+
+	if _, err := readFrame(bytes.NewReader(appendFrame(payload)[:6])); err != io.ErrUnexpectedEOF {
+		t.Fatalf("truncated frame error = %v", err)
+	}
+}
+
+// chunkReader Read 한 번에 청크 하나씩 돌려주는 연결 대용
+type chunkReader struct {
+	chunks [][]byte
+}
+
+func (r *chunkReader) Read(p []byte) (int, error) {
+	if len(r.chunks) == 0 {
+		return 0, io.EOF
+	}
+	n := copy(p, r.chunks[0])
+	r.chunks = r.chunks[1:]
+	return n, nil
+}
+
+func TestSwitchCodecReadsBufferedJSONFirst(t *testing.T) {
+	earlyJSON := []byte(`{"id":"1","type":"list_friends"}`)
+	binaryMsg, err := MsgpackCodec.Marshal(SocketMessage{ID: "2", Type: "list_friends"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// auth_success 전에 보낸 JSON이 이미 버퍼에 들어와 있는 상태에서 인코딩 전환
+	reader := bufio.NewReaderSize(&chunkReader{chunks: [][]byte{earlyJSON, appendFrame(binaryMsg)}}, 4096)
+	if _, err := reader.Peek(1); err != nil {
+		t.Fatal(err)
+	}
+
+	client := &Client{codec: JSONCodec, readCodec: JSONCodec}
+	client.switchCodec(MsgpackCodec)
+	if client.currentCodec() != MsgpackCodec {
+		t.Fatal("outgoing messages should switch immediately")
+	}
+
+	buffer := make([]byte, 4096)
+	for _, wantID := range []string{"1", "2"} {
+		codec := client.currentReadCodec(reader)
+		data, err := readRaw(codec, reader, buffer)
+		if err != nil {
+			t.Fatal(err)
+		}
+		msg, err := codec.DecodeMessage(data)
+		if err != nil {
+			t.Fatalf("message %s decoded with %s: %v", wantID, codec.Name(), err)
+		}
+		if msg.ID != wantID {
+			t.Fatalf("message id = %s, want %s", msg.ID, wantID)
+		}
+	}
+	if client.readCodec != MsgpackCodec {
+		t.Fatal("incoming messages should switch once the buffer is drained")
+	}
+}
+
+// decodeLegacy 기존 경로: interface{}로 해석한 뒤 parseMessageData에서 마샬/언마샬을 한 번 더 수행
+func decodeLegacy(data []byte, target interface{}) error {
+	var msg SocketMessage
+	if err := json.Unmarshal(data, &msg); err != nil {
+		return err
+	}
+	jsonData, err := json.Marshal(msg.Data)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(jsonData, target)
+}
+
+func decodeWith(codec Codec) func([]byte, interface{}) error {
+	return func(data []byte, target interface{}) error {
+		msg, err := codec.DecodeMessage(data)
+		if err != nil {
+			return err
+		}
+		return DecodePayload(msg.Data, target)
+	}
+}
+
+// BenchmarkDecode 수신 메시지 디코딩 비교 (wire-bytes는 메시지 하나의 크기)
+//
+//	go test ./internal/socket -run '^$' -bench . -benchmem
+func BenchmarkDecode(b *testing.B) {
+	for _, sample := range codecSamples() {
+		msg := SocketMessage{ID: "req-42", Type: sample.name, Data: sample.request}
+		jsonData, _ := JSONCodec.Marshal(msg)
+		msgpackData, _ := MsgpackCodec.Marshal(msg)
+
+		paths := []struct {
+			name    string
+			encoded []byte
+			decode  func([]byte, interface{}) error
+		}{
+			{"json-legacy", jsonData, decodeLegacy},
+			{"json", jsonData, decodeWith(JSONCodec)},
+			{"msgpack", msgpackData, decodeWith(MsgpackCodec)},
+		}
+		for _, path := range paths {
+			b.Run(sample.name+"/"+path.name, func(b *testing.B) {
+				if err := path.decode(path.encoded, sample.target()); err != nil {
+					b.Fatal(err)
+				}
+				b.ReportAllocs()
+				b.ReportMetric(float64(len(path.encoded)), "wire-bytes")
+				for i := 0; i < b.N; i++ {
+					path.decode(path.encoded, sample.target())
+				}
+			})
+		}
+	}
+}
+
+// BenchmarkEncode 송신 메시지 인코딩 비교
+func BenchmarkEncode(b *testing.B) {
+	for _, sample := range codecSamples() {
+		msg := SocketMessage{ID: "req-42", Kind: MESSAGE_KIND_REPLY, Type: sample.name, Data: sample.reply}
+		for _, codec := range codecs {
+			b.Run(sample.name+"/"+codec.Name(), func(b *testing.B) {
+				encoded, err := codec.Marshal(msg)
+				if err != nil {
+					b.Fatal(err)
+				}
+				b.ReportAllocs()
+				b.ReportMetric(float64(len(encoded)), "wire-bytes")
+				for i := 0; i < b.N; i++ {
+					codec.Marshal(msg)
+				}
+			})
+		}
+	}
+}
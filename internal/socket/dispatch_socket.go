@@ -0,0 +1,193 @@
+package socket
+
+import (
+	"game-server/internal/pkg/errors"
+	"log"
+)
+
+// MessageContext 메시지 하나를 처리하는 동안 미들웨어와 핸들러가 공유하는 정보
+type MessageContext struct {
+	Client  *Client
+	Message *SocketMessage
+	Route   *MessageRoute
+	Request interface{} // Route의 요청 DTO로 디코딩된 data (요청 DTO가 없으면 nil)
+
+	// RouteWithReply로 만든 라우트의 핸들러 결과 (핸들러가 끝난 뒤 한 번에 전송)
+	Response interface{}
+	Err      error
+	replied  bool // 핸들러가 결과를 돌려줌 (미들웨어가 거부했거나 패닉이 났으면 false)
+}
+
+type MessageHandler func(ctx *MessageContext)
+
+// MessageMiddleware 모든 핸들러를 감싸는 공통 처리 (패닉 복구, 로깅, 메트릭, 속도 제한 등)
+type MessageMiddleware func(next MessageHandler) MessageHandler
+
+// MessageRoute 소켓 메시지 타입 하나의 처리 방법
+type MessageRoute struct {
+	Type       string
+	Reply      string    // 핸들러가 돌려준 응답을 보낼 메시지 타입 (RouteWithReply로 만든 라우트만, 나머지는 핸들러가 직접 전송)
+	Public     bool      // 인증 전에도 허용
+	Quiet      bool      // 빈도가 높아 메시지마다 로그를 남기지 않음
+	RateLimit  RateLimit // 사용자별 처리 속도 제한 (zero면 제한 없음, SOCKET_RATE_LIMITS로 덮어쓰기)
+	Roles      []string  // 이 중 하나의 역할이 있어야 허용 (비어 있으면 모든 사용자)
+	Capability string    // 접속한 기기에 이 기능이 있어야 허용 (비어 있으면 모든 기기)
+
+	newRequest func() interface{}
+	handler    MessageHandler
+}
+
+// Route 요청 DTO가 있는 메시지 라우트 생성 (data를 T로 한 번에 디코딩해서 전달)
+func Route[T any](msgType string, handler func(client *Client, msg *SocketMessage, req *T)) *MessageRoute {
+	return &MessageRoute{
+		Type: msgType,
+		newRequest: func() interface{} {
+			return new(T)
+		},
+		handler: func(ctx *MessageContext) {
+			handler(ctx.Client, ctx.Message, ctx.Request.(*T))
+		},
+	}
+}
+
+// RouteNoData data가 없는 메시지 라우트 생성
+func RouteNoData(msgType string, handler func(client *Client, msg *SocketMessage)) *MessageRoute {
+	return &MessageRoute{
+		Type: msgType,
+		handler: func(ctx *MessageContext) {
+			handler(ctx.Client, ctx.Message)
+		},
+	}
+}
+
+// RouteWithReply 요청 DTO와 응답 DTO가 있는 라우트 생성
+// 핸들러는 응답만 돌려주고, 성공하면 reply 타입으로 응답하고 실패하면 error 프레임 전송
+func RouteWithReply[T, R any](msgType, reply string, handler func(client *Client, msg *SocketMessage, req *T) (R, error)) *MessageRoute {
+	return &MessageRoute{
+		Type:  msgType,
+		Reply: reply,
+		newRequest: func() interface{} {
+			return new(T)
+		},
+		handler: func(ctx *MessageContext) {
+			ctx.Response, ctx.Err = handler(ctx.Client, ctx.Message, ctx.Request.(*T))
+			ctx.replied = true
+		},
+	}
+}
+
+// RouteNoDataWithReply data 없이 응답 DTO만 있는 라우트 생성
+func RouteNoDataWithReply[R any](msgType, reply string, handler func(client *Client, msg *SocketMessage) (R, error)) *MessageRoute {
+	return &MessageRoute{
+		Type:  msgType,
+		Reply: reply,
+		handler: func(ctx *MessageContext) {
+			ctx.Response, ctx.Err = handler(ctx.Client, ctx.Message)
+			ctx.replied = true
+		},
+	}
+}
+
+// MessageRegistry 메시지 타입별 라우트와 미들웨어 체인
+type MessageRegistry struct {
+	routes      map[string]*MessageRoute
+	middlewares []MessageMiddleware
+}
+
+func NewMessageRegistry() *MessageRegistry {
+	return &MessageRegistry{
+		routes: make(map[string]*MessageRoute),
+	}
+}
+
+// Use 미들웨어 추가 (먼저 추가한 미들웨어가 바깥쪽에서 실행)
+func (r *MessageRegistry) Use(middlewares ...MessageMiddleware) {
+	r.middlewares = append(r.middlewares, middlewares...)
+}
+
+// Register 라우트 등록 (등록 시점의 미들웨어 체인으로 감쌈)
+func (r *MessageRegistry) Register(route *MessageRoute) {
+	if _, exists := r.routes[route.Type]; exists {
+		log.Fatalf("Socket message type %s registered twice", route.Type)
+	}
+
+	handler := route.handler
+	for i := len(r.middlewares) - 1; i >= 0; i-- {
+		handler = r.middlewares[i](handler)
+	}
+	route.handler = handler
+	r.routes[route.Type] = route
+}
+
+func (r *MessageRegistry) lookup(msgType string) (*MessageRoute, bool) {
+	route, ok := r.routes[msgType]
+	return route, ok
+}
+
+// dispatch 메시지 타입에 맞는 라우트를 찾아 인증 확인, data 디코딩 후 핸들러 실행
+func (s *MatchServer) dispatch(client *Client, msg *SocketMessage) {
+	if !s.beginHandler() {
+		s.sendErrorToClient(client, msg, errors.ServerShuttingDown())
+		client.Conn.Close()
+		return
+	}
+	defer s.endHandler()
+
+	if !s.checkUnauthenticatedLimit(client, msg) {
+		return
+	}
+
+	route, ok := s.registry.lookup(msg.Type)
+	if !ok {
+		if !client.authenticated {
+			s.sendErrorToClient(client, msg, errors.AuthRequired())
+			return
+		}
+		s.sendErrorToClient(client, msg, errors.UnknownMessageType())
+		return
+	}
+
+	if !route.Public && !client.authenticated {
+		s.sendErrorToClient(client, msg, errors.AuthRequired())
+		return
+	}
+
+	ctx := &MessageContext{
+		Client:  client,
+		Message: msg,
+		Route:   route,
+	}
+	if route.newRequest != nil {
+		ctx.Request = route.newRequest()
+		if err := s.parseMessageData(msg.Data, ctx.Request); err != nil {
+			s.sendErrorToClient(client, msg, errors.InvalidInput())
+			return
+		}
+	}
+
+	// 같은 ID로 재전송된 요청은 다시 처리하지 않음
+	if client.authenticated {
+		if !s.beginRequest(client, msg) {
+			return
+		}
+		defer s.finishRequest(client, msg)
+	}
+
+	route.handler(ctx)
+	s.respond(ctx)
+}
+
+// respond RouteWithReply 핸들러의 결과 전송 (에러면 error 프레임, 아니면 라우트의 Reply 타입으로 응답)
+func (s *MatchServer) respond(ctx *MessageContext) {
+	if !ctx.replied {
+		return
+	}
+	if ctx.Err != nil {
+		s.sendErrorToClient(ctx.Client, ctx.Message, ctx.Err)
+		return
+	}
+	s.reply(ctx.Client, ctx.Message, SocketMessage{
+		Type: ctx.Route.Reply,
+		Data: ctx.Response,
+	})
+}
@@ -0,0 +1,70 @@
+package socket
+
+import (
+	"encoding/json"
+	"game-server/internal/dto"
+	"game-server/internal/pkg/errors"
+	"testing"
+)
+
+func decodeFrames(t *testing.T, conn *frameConn) []map[string]interface{} {
+	t.Helper()
+	var frames []map[string]interface{}
+	for _, data := range conn.sent() {
+		var frame map[string]interface{}
+		if err := json.Unmarshal(data, &frame); err != nil {
+			t.Fatal(err)
+		}
+		frames = append(frames, frame)
+	}
+	return frames
+}
+
+func TestRouteWithReplySendsTypedResponse(t *testing.T) {
+	s := &MatchServer{registry: NewMessageRegistry()}
+	s.registry.Use(s.authorizeMiddleware)
+	s.registry.Register(RouteWithReply("create_match", "match_created", func(client *Client, msg *SocketMessage, req *dto.CreateMatchRequest) (*dto.CreateMatchResponse, error) {
+		if req.MaxPlayers <= 0 {
+			return nil, errors.BadRequestWithMessage("maxPlayers")
+		}
+		return &dto.CreateMatchResponse{GameID: req.GameID, MaxPlayers: req.MaxPlayers}, nil
+	}))
+	restricted := RouteNoDataWithReply("restricted", "restricted_ok", func(client *Client, msg *SocketMessage) (map[string]string, error) {
+		t.Error("handler should not run for a rejected request")
+		return nil, nil
+	})
+	restricted.Roles = []string{"admin"}
+	s.registry.Register(restricted)
+
+	conn := &frameConn{}
+	client := &Client{ID: "client-1", UserID: "user-1", Conn: conn, codec: JSONCodec, readCodec: JSONCodec, authenticated: true}
+	dispatch := func(msgType, data string) {
+		msg, err := JSONCodec.DecodeMessage([]byte(`{"type":"` + msgType + `","data":` + data + `}`))
+		if err != nil {
+			t.Fatal(err)
+		}
+		s.dispatch(client, msg)
+	}
+
+	dispatch("create_match", `{"gameId":"battle-arena","maxPlayers":4}`)
+	dispatch("create_match", `{"gameId":"battle-arena","maxPlayers":0}`)
+	dispatch("restricted", `{}`)
+
+	frames := decodeFrames(t, conn)
+	if len(frames) != 3 {
+		t.Fatalf("frames = %v, want one per request", frames)
+	}
+	reply := frames[0]
+	if reply["type"] != "match_created" || reply["kind"] != MESSAGE_KIND_REPLY {
+		t.Fatalf("reply = %v", reply)
+	}
+	if data := reply["data"].(map[string]interface{}); data["gameId"] != "battle-arena" || data["maxPlayers"] != float64(4) {
+		t.Fatalf("reply data = %v", data)
+	}
+	if frames[1]["type"] != "error" || frames[1]["data"].(map[string]interface{})["code"] != "BAD_REQUEST" {
+		t.Fatalf("handler error should become an error frame, got %v", frames[1])
+	}
+	if frames[2]["type"] != "error" || frames[2]["data"].(map[string]interface{})["code"] != "FORBIDDEN" {
+		t.Fatalf("rejected request should only get the middleware error, got %v", frames[2])
+	}
+}
@@ -0,0 +1,77 @@
+package socket
+
+import (
+	"encoding/json"
+	"game-server/internal/dto"
+	"game-server/internal/pkg/database"
+	"game-server/internal/service"
+	"log"
+)
+
+// watchFriendEvents 친구 요청/수락/삭제 알림을 이 노드에 접속한 대상 사용자의 모든 세션으로 전달
+// REST API에서 발생한 변경도 같은 채널로 들어옴
+// 친구 삭제면 두 사용자의 서로에 대한 접속 상태 구독도 해제
+func (s *MatchServer) watchFriendEvents() {
+	pubsub := database.Subscribe(service.FRIEND_CHANNEL)
+	defer pubsub.Close()
+
+	for message := range pubsub.Channel() {
+		var event dto.FriendEvent
+		if err := json.Unmarshal([]byte(message.Payload), &event); err != nil {
+			log.Printf("Invalid friend event: %v", err)
+			continue
+		}
+
+		if event.Type == service.FRIEND_EVENT_REMOVED {
+			s.dropPresencePair(event.UserID, event.FromUserID)
+		}
+		s.sendToUser(event.UserID, SocketMessage{
+			Type: event.Type,
+			Data: event,
+		}, "")
+	}
+}
+
+func (s *MatchServer) handleListFriends(client *Client, msg *SocketMessage) (*dto.FriendListResponse, error) {
+	return s.friendService.ListFriends(client.UserID)
+}
+
+func (s *MatchServer) handleSendFriendRequest(client *Client, msg *SocketMessage, req *dto.FriendTargetRequest) (*dto.FriendRequestResponse, error) {
+	return s.friendService.SendRequest(client.UserID, req.UserID)
+}
+
+func (s *MatchServer) handleAcceptFriendRequest(client *Client, msg *SocketMessage, req *dto.FriendTargetRequest) (*dto.FriendInfo, error) {
+	return s.friendService.AcceptRequest(client.UserID, req.UserID)
+}
+
+func (s *MatchServer) handleDeclineFriendRequest(client *Client, msg *SocketMessage, req *dto.FriendTargetRequest) {
+	if err := s.friendService.DeclineRequest(client.UserID, req.UserID); err != nil {
+		s.sendErrorToClient(client, msg, err)
+		return
+	}
+	s.ack(client, msg)
+}
+
+func (s *MatchServer) handleRemoveFriend(client *Client, msg *SocketMessage, req *dto.FriendTargetRequest) {
+	if err := s.friendService.RemoveFriend(client.UserID, req.UserID); err != nil {
+		s.sendErrorToClient(client, msg, err)
+		return
+	}
+	s.ack(client, msg)
+}
+
+func (s *MatchServer) handleBlockUser(client *Client, msg *SocketMessage, req *dto.FriendTargetRequest) {
+	if err := s.friendService.Block(client.UserID, req.UserID); err != nil {
+		s.sendErrorToClient(client, msg, err)
+		return
+	}
+	s.ack(client, msg)
+}
+
+func (s *MatchServer) handleUnblockUser(client *Client, msg *SocketMessage, req *dto.FriendTargetRequest) {
+	if err := s.friendService.Unblock(client.UserID, req.UserID); err != nil {
+		s.sendErrorToClient(client, msg, err)
+		return
+	}
+	s.ack(client, msg)
+}
@@ -0,0 +1,137 @@
+package socket
+
+import (
+	"game-server/internal/dto"
+	"game-server/internal/service"
+	"log"
+)
+
+// startGameSession 시작된 매치로부터 게임 세션을 만들고 참가자들에게 알림
+func (s *MatchServer) startGameSession(matchID string, teams []dto.Team) {
+	matchInfo, err := s.matchService.GetMatchInfo(matchID)
+	if err != nil {
+		log.Printf("Failed to load match %s for game session: %v", matchID, err)
+		return
+	}
+
+	game, err := s.gameService.CreateGame(matchInfo, teams)
+	if err != nil {
+		log.Printf("Failed to create game session for match %s: %v", matchID, err)
+		return
+	}
+
+	started, err := s.gameService.StartGame(game.ID)
+	if err != nil {
+		log.Printf("Failed to start game %s: %v", game.ID, err)
+		return
+	}
+
+	s.notifyGamePlayers(game, SocketMessage{
+		Type: "game_started",
+		Data: started,
+	}, "")
+	s.refreshGamePresence(game)
+
+	log.Printf("Game %s started for match %s with %d players", game.ID, matchID, game.PlayerCount)
+}
+
+func (s *MatchServer) handleGameEvent(client *Client, msg *SocketMessage, req *dto.GameEventRequest) {
+	// 서비스로 위임
+	game, err := s.gameService.HandleEvent(client.UserID, req)
+	if err != nil {
+		s.sendErrorToClient(client, msg, err)
+		return
+	}
+
+	s.ack(client, msg)
+
+	// 호스트의 종료 이벤트는 게임을 끝냄
+	if req.Type == service.GAME_EVENT_END && game.HostID == client.UserID {
+		s.endGameSession(game, "host_ended")
+		return
+	}
+
+	event := SocketMessage{
+		Type: "game_event",
+		Data: map[string]interface{}{
+			"gameId": game.ID,
+			"userId": client.UserID,
+			"type":   req.Type,
+			"data":   req.Data,
+		},
+	}
+
+	if req.Type == service.GAME_EVENT_CHAT {
+		s.relayGameChat(game, event, client.UserID)
+		return
+	}
+
+	// 다른 참가자들에게 이벤트 전달
+	s.notifyGamePlayers(game, event, client.UserID)
+}
+
+// relayGameChat 채팅은 보낸 사람과 차단 관계가 없는 참가자의 채팅 가능한 세션에 전달
+func (s *MatchServer) relayGameChat(game *service.Game, msg SocketMessage, senderID string) {
+	playerIDs := make([]string, 0, len(game.Players))
+	for _, player := range game.Players {
+		playerIDs = append(playerIDs, player.UserID)
+	}
+
+	blocked, err := s.friendService.BlockedAmong(senderID, playerIDs)
+	if err != nil {
+		log.Printf("Failed to check blocks for chat in game %s: %v", game.ID, err)
+		return
+	}
+
+	for _, player := range game.Players {
+		if player.UserID == senderID || player.Status != service.PLAYER_STATUS_PLAYING || blocked[player.UserID] {
+			continue
+		}
+		s.sendToUser(player.UserID, msg, service.SESSION_CAPABILITY_CHAT)
+	}
+}
+
+// disconnectFromGame 연결이 끊긴 플레이어를 게임에서 제외하고 필요하면 게임을 종료
+func (s *MatchServer) disconnectFromGame(userID string) {
+	game, disconnected, err := s.gameService.DisconnectPlayer(userID)
+	if err != nil || disconnected == nil {
+		return
+	}
+
+	s.notifyGamePlayers(game, SocketMessage{
+		Type: "player_disconnected",
+		Data: disconnected,
+	}, userID)
+
+	log.Printf("User %s disconnected from game %s", userID, game.ID)
+
+	if s.gameService.ShouldEnd(game) {
+		s.endGameSession(game, "team_disconnected")
+	}
+}
+
+func (s *MatchServer) endGameSession(game *service.Game, reason string) {
+	gameInfo, err := s.gameService.EndGame(game.ID, reason)
+	if err != nil {
+		log.Printf("Failed to end game %s: %v", game.ID, err)
+		return
+	}
+
+	s.notifyGamePlayers(game, SocketMessage{
+		Type: "game_ended",
+		Data: gameInfo,
+	}, "")
+	s.refreshGamePresence(game)
+
+	log.Printf("Game %s ended (%s)", game.ID, reason)
+}
+
+func (s *MatchServer) notifyGamePlayers(game *service.Game, msg SocketMessage, excludeUserID string) {
+	for _, player := range game.Players {
+		if player.UserID == excludeUserID || player.Status != service.PLAYER_STATUS_PLAYING {
+			continue
+		}
+		// 게임 진행은 플레이 가능한 기기(PC/콘솔)에만 전송
+		s.sendToUser(player.UserID, msg, service.SESSION_CAPABILITY_PLAY)
+	}
+}
@@ -0,0 +1,81 @@
+package socket
+
+import (
+	"game-server/internal/dto"
+	"game-server/internal/pkg/database/redistest"
+	"game-server/internal/service"
+	"net"
+	"sync"
+	"testing"
+	"time"
+)
+
+// recordingConn 보낸 메시지 수만 기록하는 연결
+type recordingConn struct {
+	net.Conn
+	mu     sync.Mutex
+	writes int
+}
+
+func (c *recordingConn) Write(p []byte) (int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.writes++
+	return len(p), nil
+}
+
+func (c *recordingConn) count() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.writes
+}
+
+func TestRelayGameChatUsesChatSessionsAndSkipsBlocked(t *testing.T) {
+	redis := redistest.Start(t)
+	sessions := service.NewSessionService(service.SESSION_POLICY_MULTI)
+	s := &MatchServer{
+		clients:        make(map[string]*Client),
+		sessionService: sessions,
+		friendService:  service.NewFriendService(),
+	}
+
+	// 차단 목록 캐시 (blocker가 sender를 차단)
+	for _, userID := range []string{"sender", "friend", "blocker"} {
+		redis.SAdd("blocks:"+userID, "-")
+	}
+	redis.SAdd("blocks:blocker", "sender")
+
+	connect := func(userID, socketID, device string) *recordingConn {
+		conn := &recordingConn{}
+		_, capabilities, _ := sessions.DeviceCapabilities(device)
+		if err := sessions.Open(userID, &dto.SessionInfo{SocketID: socketID, Device: device, Capabilities: capabilities, ConnectedAt: time.Now()}); err != nil {
+			t.Fatal(err)
+		}
+		s.clients[socketID] = &Client{ID: socketID, UserID: userID, Conn: conn, codec: JSONCodec}
+		return conn
+	}
+	senderPC := connect("sender", "sender-pc", service.DEVICE_PC)
+	friendPC := connect("friend", "friend-pc", service.DEVICE_PC)
+	friendMobile := connect("friend", "friend-mobile", service.DEVICE_MOBILE)
+	blockerPC := connect("blocker", "blocker-pc", service.DEVICE_PC)
+
+	game := &service.Game{
+		ID: "game-1",
+		Players: []dto.MatchPlayer{
+			{UserID: "sender", Status: service.PLAYER_STATUS_PLAYING},
+			{UserID: "friend", Status: service.PLAYER_STATUS_PLAYING},
+			{UserID: "blocker", Status: service.PLAYER_STATUS_PLAYING},
+		},
+	}
+	s.relayGameChat(game, SocketMessage{Type: "game_event"}, "sender")
+
+	if friendPC.count() != 1 || friendMobile.count() != 1 {
+		t.Fatalf("friend sessions got pc=%d mobile=%d, want chat on every can_chat session", friendPC.count(), friendMobile.count())
+	}
+	if blockerPC.count() != 0 {
+		t.Fatal("chat must not reach a player who blocked the sender")
+	}
+	if senderPC.count() != 0 {
+		t.Fatal("chat should not be echoed to the sender")
+	}
+}
@@ -0,0 +1,74 @@
+package socket
+
+import (
+	"game-server/internal/dto"
+	"game-server/internal/pkg/errors"
+	"slices"
+)
+
+const (
+	// 버전 정보 없이 token만 보내는 기존 클라이언트의 프로토콜 버전
+	PROTOCOL_VERSION_LEGACY = 1
+
+	// 서버가 지원하는 프로토콜 버전 범위
+	// 2: 요청 ID / kind 필드, 에러 코드가 담긴 error 프레임
+	PROTOCOL_VERSION_MIN = 1
+	PROTOCOL_VERSION_MAX = 2
+
+	// 협상 가능한 기능 이름
+	CAPABILITY_BINARY      = "binary"      // JSON 대신 MessagePack
+	CAPABILITY_COMPRESSION = "compression" // 메시지 단위 DEFLATE 압축
+)
+
+// negotiateProtocol 클라이언트 버전이 지원 범위인지 확인하고 이 연결에서 사용할 기능 결정
+func (s *MatchServer) negotiateProtocol(client *Client, req *dto.SocketAuthRequest) error {
+	version := req.ProtocolVersion
+	if version == 0 {
+		version = PROTOCOL_VERSION_LEGACY
+	}
+	if version < PROTOCOL_VERSION_MIN || version > PROTOCOL_VERSION_MAX {
+		return errors.UpgradeRequired(version, PROTOCOL_VERSION_MIN, PROTOCOL_VERSION_MAX)
+	}
+
+	client.ProtocolVersion = version
+	client.ClientBuild = req.ClientBuild
+	client.Capabilities = []string{}
+	for _, capability := range req.Capabilities {
+		if slices.Contains(s.capabilities, capability) && !slices.Contains(client.Capabilities, capability) {
+			client.Capabilities = append(client.Capabilities, capability)
+		}
+	}
+	return nil
+}
+
+// sendUpgradeRequired 지원 범위를 upgrade_required 메시지로 먼저 알린 뒤 같은 내용의 에러 프레임 전송
+// 에러 프레임만 처리하는 클라이언트도 UPGRADE_REQUIRED 코드로 알 수 있음
+func (s *MatchServer) sendUpgradeRequired(client *Client, msg *SocketMessage, version int) {
+	s.sendToClient(client, SocketMessage{
+		Type: "upgrade_required",
+		Data: dto.UpgradeRequiredResponse{
+			ClientVersion:      version,
+			MinProtocolVersion: PROTOCOL_VERSION_MIN,
+			MaxProtocolVersion: PROTOCOL_VERSION_MAX,
+			Message:            "Client protocol version is not supported, update the game",
+		},
+	})
+	s.sendErrorToClient(client, msg, errors.UpgradeRequired(version, PROTOCOL_VERSION_MIN, PROTOCOL_VERSION_MAX))
+}
+
+// negotiatedCodec 협상된 기능에 맞는 인코딩 (아무 기능도 없으면 JSON)
+func (c *Client) negotiatedCodec() Codec {
+	codec := JSONCodec
+	if c.hasCapability(CAPABILITY_BINARY) {
+		codec = MsgpackCodec
+	}
+	if c.hasCapability(CAPABILITY_COMPRESSION) {
+		codec = compressed(codec)
+	}
+	return codec
+}
+
+// hasCapability 협상된 기능인지 확인
+func (c *Client) hasCapability(capability string) bool {
+	return slices.Contains(c.Capabilities, capability)
+}
@@ -0,0 +1,60 @@
+package socket
+
+import (
+	"game-server/internal/dto"
+	"slices"
+	"testing"
+)
+
+func TestNegotiateProtocol(t *testing.T) {
+	s := &MatchServer{capabilities: []string{CAPABILITY_BINARY}}
+
+	legacy := &Client{}
+	if err := s.negotiateProtocol(legacy, &dto.SocketAuthRequest{Token: "t"}); err != nil {
+		t.Fatalf("legacy client without a version should be accepted: %v", err)
+	}
+	if legacy.ProtocolVersion != PROTOCOL_VERSION_LEGACY {
+		t.Fatalf("legacy version = %d", legacy.ProtocolVersion)
+	}
+
+	client := &Client{}
+	err := s.negotiateProtocol(client, &dto.SocketAuthRequest{
+		ProtocolVersion: PROTOCOL_VERSION_MAX,
+		Capabilities:    []string{CAPABILITY_BINARY, "compression", CAPABILITY_BINARY},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(client.Capabilities, []string{CAPABILITY_BINARY}) {
+		t.Fatalf("only server-enabled capabilities should be negotiated, got %v", client.Capabilities)
+	}
+
+	for _, version := range []int{-1, PROTOCOL_VERSION_MAX + 1} {
+		if err := s.negotiateProtocol(&Client{}, &dto.SocketAuthRequest{ProtocolVersion: version}); err == nil {
+			t.Errorf("version %d should require an upgrade", version)
+		}
+	}
+}
+
+func TestNegotiatedCodec(t *testing.T) {
+	s := &MatchServer{capabilities: []string{CAPABILITY_BINARY, CAPABILITY_COMPRESSION}}
+
+	cases := []struct {
+		capabilities []string
+		want         Codec
+	}{
+		{nil, JSONCodec},
+		{[]string{CAPABILITY_BINARY}, MsgpackCodec},
+		{[]string{CAPABILITY_COMPRESSION}, compressed(JSONCodec)},
+		{[]string{CAPABILITY_COMPRESSION, CAPABILITY_BINARY}, compressed(MsgpackCodec)},
+	}
+	for _, tc := range cases {
+		client := &Client{}
+		if err := s.negotiateProtocol(client, &dto.SocketAuthRequest{ProtocolVersion: PROTOCOL_VERSION_MAX, Capabilities: tc.capabilities}); err != nil {
+			t.Fatal(err)
+		}
+		if got := client.negotiatedCodec(); got != tc.want {
+			t.Errorf("capabilities %v: codec = %s, want %s", tc.capabilities, got.Name(), tc.want.Name())
+		}
+	}
+}
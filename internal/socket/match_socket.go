@@ -1,257 +1,450 @@
 package socket
 
 import (
+	"bufio"
+	"crypto/tls"
 	"encoding/json"
 	"fmt"
+	"game-server/internal/config"
 	"game-server/internal/dto"
 	"game-server/internal/pkg/auth"
 	"game-server/internal/pkg/database"
+	"game-server/internal/pkg/errors"
 	"game-server/internal/service"
 	"log"
 	"net"
 	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
 )
 
 type Client struct {
 	ID     string
+	IP     string
 	UserID string
+
+	countedIP string // IP별 연결 수에 포함된 주소 (PROXY 헤더를 읽기 전에는 비어 있음)
+
+	// TLS 클라이언트 인증서로 검증된 내부 서비스 이름 (인증서 CN)
+	ServiceName string
+
+	Claims *auth.Claims
 	Conn   net.Conn
+
+	authenticated bool
+	handedOff     atomic.Bool // 서버 종료나 세션 교체로 끊는 연결 (매치/게임 상태는 유지)
+
+	// auth 핸드셰이크에서 협상된 프로토콜 정보
+	ProtocolVersion int
+	ClientBuild     string
+	Capabilities    []string
+	codecMux        sync.RWMutex
+	codec           Codec // 보내는 메시지 인코딩 (인증 후 binary 기능이 협상되면 MessagePack으로 전환)
+	readCodec       Codec // 받는 메시지 인코딩 (읽기 루프에서만 사용)
+	nextCodec       Codec // 버퍼에 남은 데이터를 다 읽은 뒤 전환할 받는 메시지 인코딩
+
+	// 기기 종류와 그에 따라 허용되는 기능 (알림을 보낼 세션을 고를 때 사용)
+	Device             string
+	DeviceCapabilities []string
+
+	tokenMux        sync.Mutex
+	tokenGeneration int
+	warningTimer    *time.Timer
+	expiryTimer     *time.Timer
+
+	replayMux sync.Mutex
+	replay    *replayPlayer
+
+	presenceWatching map[string]struct{} // 접속 상태를 구독 중인 사용자 (MatchServer.presenceMux로 보호)
+
+	limitersMux     sync.Mutex
+	limiters        map[string]*tokenBucket // Redis 장애 시 사용하는 연결 단위 제한
+	violations      int
+	violationsSince time.Time
 }
 
 type SocketMessage struct {
+	ID   string      `json:"id,omitempty"`   // 클라이언트가 붙인 요청 ID (응답에 그대로 돌려줌)
+	Kind string      `json:"kind,omitempty"` // event 또는 reply
 	Type string      `json:"type"`
 	Data interface{} `json:"data"`
 	From string      `json:"from,omitempty"`
+
+	answered bool // 요청에 응답이나 에러를 보냈는지 (dispatch가 응답 없이 끝난 요청을 정리할 때 사용)
 }
 
 type MatchServer struct {
-	listener     net.Listener
-	clients      map[string]*Client // socketId -> Client
-	clientsMux   sync.RWMutex
-	matchService *service.MatchService
+	listener        net.Listener
+	clients         map[string]*Client // socketId -> Client
+	clientsMux      sync.RWMutex
+	matchService    *service.MatchService
+	gameService     *service.GameService
+	replayService   *service.ReplayService
+	sessionService  *service.SessionService
+	presenceService *service.PresenceService
+	friendService   *service.FriendService
+	capabilities    []string // 서버에서 켜져 있는 기능
+	registry        *MessageRegistry
+
+	// 접속 상태 구독 (구독 대상 userId -> 구독 중인 연결)
+	presenceMux  sync.RWMutex
+	presenceSubs map[string]map[*Client]struct{}
+
+	// 처리 속도 제한
+	rateLimits      map[string]config.RateLimitRule // 메시지 타입별 기본 제한 덮어쓰기
+	unauthRateLimit RateLimit
+	maxViolations   int
+	violationWindow time.Duration
+
+	// 종료 처리 (closing 이후에는 새 연결과 요청을 받지 않음)
+	drainMux  sync.RWMutex
+	closing   atomic.Bool
+	stop      chan struct{}  // 종료할 때 닫혀서 백그라운드 작업(인증서 재로드 등)을 멈춤
+	handlerWG sync.WaitGroup // 진행 중인 메시지 핸들러
+	connWG    sync.WaitGroup // 열려 있는 연결 고루틴
+	connsMux  sync.Mutex
+	conns     map[*Client]struct{} // 인증 전 연결을 포함한 모든 연결
+
+	// 연결 수락 제한
+	maxConnections      int
+	maxConnectionsPerIP int
+	connsPerIP          map[string]int
+	authTimeout         time.Duration
+	proxyProtocol       bool
+
+	tlsSettings config.TLSConfig
+	tlsConfig   *tls.Config
 }
 
 const (
 	INVITE_EXPIRE_MINUTES = 5
 )
 
-func NewMatchServer(matchService *service.MatchService) *MatchServer {
-	return &MatchServer{
-		clients:      make(map[string]*Client),
-		matchService: matchService,
-	}
+func NewMatchServer(cfg config.SocketConfig, matchService *service.MatchService, gameService *service.GameService, replayService *service.ReplayService, sessionService *service.SessionService, presenceService *service.PresenceService, friendService *service.FriendService) *MatchServer {
+	server := &MatchServer{
+		clients:             make(map[string]*Client),
+		matchService:        matchService,
+		gameService:         gameService,
+		replayService:       replayService,
+		sessionService:      sessionService,
+		presenceService:     presenceService,
+		friendService:       friendService,
+		presenceSubs:        make(map[string]map[*Client]struct{}),
+		capabilities:        []string{CAPABILITY_BINARY, CAPABILITY_COMPRESSION},
+		registry:            NewMessageRegistry(),
+		stop:                make(chan struct{}),
+		conns:               make(map[*Client]struct{}),
+		maxConnections:      cfg.MaxConnections,
+		maxConnectionsPerIP: cfg.MaxConnectionsPerIP,
+		connsPerIP:          make(map[string]int),
+		authTimeout:         cfg.AuthTimeout,
+		proxyProtocol:       cfg.ProxyProtocol,
+		tlsSettings:         cfg.TLS,
+		rateLimits:          cfg.RateLimits,
+		unauthRateLimit:     RateLimit{Rate: cfg.UnauthRateLimit.Rate, Burst: cfg.UnauthRateLimit.Burst},
+		maxViolations:       cfg.MaxViolations,
+		violationWindow:     cfg.ViolationWindow,
+	}
+	server.registerRoutes()
+	return server
 }
 
 func (s *MatchServer) Start(port string) error {
+	tlsConfig, err := newTLSConfig(s.tlsSettings, s.stop)
+	if err != nil {
+		return fmt.Errorf("failed to configure match server TLS: %v", err)
+	}
+	s.tlsConfig = tlsConfig
+
 	listener, err := net.Listen("tcp", ":"+port)
 	if err != nil {
 		return fmt.Errorf("failed to start match server: %v", err)
 	}
 	s.listener = listener
 
-	log.Printf("Match Server listening on port %s", port)
+	if s.tlsConfig != nil {
+		log.Printf("Match Server listening on port %s (TLS)", port)
+	} else {
+		log.Printf("Match Server listening on port %s", port)
+	}
+
+	// 첫 세션이 등록되기 전에 노드가 살아 있음을 표시
+	if err := s.sessionService.Heartbeat(); err != nil {
+		log.Printf("Failed to register node heartbeat: %v", err)
+	}
+	go s.heartbeatSessions()
+	go s.watchBans()
+	go s.watchRevocations()
+	go s.watchSessions()
+	go s.watchPresence()
+	go s.watchFriendEvents()
 
 	for {
 		conn, err := listener.Accept()
 		if err != nil {
+			if s.closing.Load() {
+				return nil
+			}
 			log.Printf("Error accepting connection: %v", err)
 			continue
 		}
 
+		s.connWG.Add(1)
 		go s.handleConnection(conn)
 	}
 }
 
 func (s *MatchServer) handleConnection(conn net.Conn) {
-	clientAddr := conn.RemoteAddr().String()
-	log.Printf("New client connected: %s", clientAddr)
+	defer s.connWG.Done()
+	defer conn.Close()
 
+	clientAddr := conn.RemoteAddr().String()
+	// 노드와 로드밸런서에 상관없이 유일한 ID (세션 매핑의 비교 삭제에 사용)
 	client := &Client{
-		ID:   clientAddr,
-		Conn: conn,
+		ID:        uuid.New().String(),
+		IP:        hostOf(conn.RemoteAddr()),
+		Conn:      conn,
+		codec:     JSONCodec,
+		readCodec: JSONCodec,
+	}
+	defer s.recoverPanic(client, nil)
+
+	// PROXY 헤더나 TLS 핸드셰이크를 기다리는 연결도 전체 제한에 포함되도록 Accept 직후 등록
+	if err := s.admitConn(client); err != nil {
+		s.rejectConn(client, clientAddr, err)
+		return
+	}
+	defer s.releaseConn(client)
+
+	reader := bufio.NewReaderSize(conn, 4096)
+
+	// 로드밸런서가 붙인 PROXY 헤더에서 실제 클라이언트 IP 확인
+	if s.proxyProtocol {
+		conn.SetReadDeadline(time.Now().Add(PROXY_HEADER_TIMEOUT))
+		addr, err := readProxyHeader(reader)
+		if err != nil {
+			log.Printf("Rejected connection from %s: %v", clientAddr, err)
+			return
+		}
+		ip := client.IP // LOCAL 명령(로드밸런서 헬스체크)이면 연결 주소 그대로
+		if addr != nil {
+			ip = hostOf(addr)
+		}
+		if err := s.admitIP(client, ip); err != nil {
+			s.rejectConn(client, clientAddr, err)
+			return
+		}
+	}
+
+	// 인증 제한 시간 (TLS 핸드셰이크 포함, 인증에 성공하면 해제)
+	var authDeadline time.Time
+	if s.authTimeout > 0 {
+		authDeadline = time.Now().Add(s.authTimeout)
+	}
+	conn.SetDeadline(authDeadline)
+
+	if s.tlsConfig != nil {
+		tlsConn, err := s.startTLS(client, conn, reader)
+		if err != nil {
+			log.Printf("TLS handshake with %s failed: %v", clientAddr, err)
+			return
+		}
+		client.Conn = tlsConn
+		reader = bufio.NewReaderSize(tlsConn, 4096)
 	}
+	conn.SetWriteDeadline(time.Time{})
 
 	defer s.removeClient(client.ID)
-	defer conn.Close()
+
+	if client.ServiceName != "" {
+		log.Printf("New client %s connected from %s (ip %s, service %s)", client.ID, clientAddr, client.IP, client.ServiceName)
+	} else {
+		log.Printf("New client %s connected from %s (ip %s)", client.ID, clientAddr, client.IP)
+	}
 
 	buffer := make([]byte, 4096)
-	authenticated := false
 
 	for {
-		n, err := conn.Read(buffer)
+		codec := client.currentReadCodec(reader)
+		data, err := readRaw(codec, reader, buffer)
 		if err != nil {
+			if netErr, ok := err.(net.Error); ok && netErr.Timeout() && !client.authenticated {
+				log.Printf("Closing client %s: auth not completed within %s", clientAddr, s.authTimeout)
+				s.sendErrorToClient(client, nil, errors.AuthTimeout())
+				return
+			}
 			log.Printf("Error reading from client %s: %v", clientAddr, err)
 			return
 		}
 
-		var msg SocketMessage
-		if err := json.Unmarshal(buffer[:n], &msg); err != nil {
+		msg, err := codec.DecodeMessage(data)
+		if err != nil {
 			log.Printf("Error parsing message from %s: %v", clientAddr, err)
 			continue
 		}
 
-		// 인증 검사
-		if !authenticated {
-			if msg.Type != "auth" {
-				s.sendErrorToClient(client, "Authentication required")
-				continue
-			}
+		msg.From = client.ID
+		s.dispatch(client, msg)
+	}
+}
 
-			if !s.authenticateClient(client, &msg) {
-				continue
-			}
+// hostOf 주소에서 포트를 뺀 IP
+func hostOf(addr net.Addr) string {
+	host, _, err := net.SplitHostPort(addr.String())
+	if err != nil {
+		return addr.String()
+	}
+	return host
+}
 
-			authenticated = true
-			s.addClient(client)
-			log.Printf("Client %s authenticated as user %s", clientAddr, client.UserID)
-			continue
+// readRaw 메시지 하나의 원본 바이트 읽기
+// JSON은 기존처럼 Read 한 번이 메시지 하나, 바이너리는 길이 헤더가 붙은 프레임 단위
+func readRaw(codec Codec, reader *bufio.Reader, buffer []byte) ([]byte, error) {
+	if codec == JSONCodec {
+		n, err := reader.Read(buffer)
+		if err != nil {
+			return nil, err
 		}
-
-		msg.From = client.ID
-		s.handleMessage(client, &msg)
+		return buffer[:n], nil
 	}
+	return readFrame(reader)
 }
 
-func (s *MatchServer) authenticateClient(client *Client, msg *SocketMessage) bool {
-	var authData map[string]interface{}
-	if msgData, ok := msg.Data.(map[string]interface{}); ok {
-		authData = msgData
+func (s *MatchServer) authenticateClient(client *Client, msg *SocketMessage, req *dto.SocketAuthRequest) {
+	if client.authenticated {
+		s.sendErrorToClient(client, msg, errors.BadRequestWithMessage("이미 인증된 연결입니다. 토큰 갱신은 reauth를 사용하세요"))
+		return
 	}
 
-	token, ok := authData["token"].(string)
-	if !ok || token == "" {
-		s.sendErrorToClient(client, "Token is required")
-		return false
+	// 지원하지 않는 버전은 토큰 검증 전에 업데이트 안내 후 연결 종료
+	if err := s.negotiateProtocol(client, req); err != nil {
+		log.Printf("Client %s rejected: protocol version %d (build %s) not supported", client.ID, req.ProtocolVersion, req.ClientBuild)
+		s.sendUpgradeRequired(client, msg, req.ProtocolVersion)
+		client.Conn.Close()
+		return
 	}
 
-	// JWT 토큰 검증
-	jwtToken, err := auth.ValidateAccessToken(token)
+	token := req.Token
+	if token == "" {
+		s.sendErrorToClient(client, msg, errors.TokenRequired())
+		return
+	}
+
+	// JWT 토큰 및 클레임 검증
+	claims, err := auth.ValidateAccessToken(token)
 	if err != nil {
-		s.sendErrorToClient(client, "Invalid token")
-		return false
+		s.sendErrorToClient(client, msg, authError(err))
+		return
 	}
 
-	// 사용자 ID 추출
-	userID, err := auth.GetUserIDFromToken(jwtToken)
+	device, deviceCapabilities, err := s.sessionService.DeviceCapabilities(req.Device)
 	if err != nil {
-		s.sendErrorToClient(client, "Failed to get user ID from token")
-		return false
+		s.sendErrorToClient(client, msg, err)
+		return
 	}
 
-	client.UserID = userID
+	userID := claims.UserID
 
-	// Redis에 socket_id와 user_id 매핑 저장
-	if err := database.HSet("socket:users", client.ID, userID); err != nil {
-		log.Printf("Failed to store socket mapping: %v", err)
-	}
-	if err := database.HSet("user:sockets", userID, client.ID); err != nil {
-		log.Printf("Failed to store user mapping: %v", err)
+	// 세션 정책에 따라 등록 (kick_old면 같은 기기의 기존 세션은 session_replaced를 받고 종료됨)
+	if err := s.sessionService.Open(userID, &dto.SessionInfo{
+		SocketID:     client.ID,
+		Device:       device,
+		Capabilities: deviceCapabilities,
+		ConnectedAt:  time.Now(),
+	}); err != nil {
+		log.Printf("Client %s rejected: user %s already has an active session", client.ID, userID)
+		s.sendErrorToClient(client, msg, err)
+		client.Conn.Close()
+		return
 	}
 
+	client.UserID = userID
+	client.Device = device
+	client.DeviceCapabilities = deviceCapabilities
+	s.setClaims(client, claims)
+
+	// 인증은 중복 요청 캐시 대상이 아니므로 응답 저장 없이 전송
 	s.sendToClient(client, SocketMessage{
+		ID:   msg.ID,
+		Kind: MESSAGE_KIND_REPLY,
 		Type: "auth_success",
-		Data: map[string]string{"userId": userID},
+		Data: dto.SocketAuthResponse{
+			UserID:             userID,
+			ProtocolVersion:    client.ProtocolVersion,
+			MinProtocolVersion: PROTOCOL_VERSION_MIN,
+			MaxProtocolVersion: PROTOCOL_VERSION_MAX,
+			Capabilities:       client.Capabilities,
+			Device:             client.Device,
+			DeviceCapabilities: client.DeviceCapabilities,
+		},
 	})
 
-	return true
-}
-
-func (s *MatchServer) handleMessage(client *Client, msg *SocketMessage) {
-	switch msg.Type {
-	case "create_match":
-		s.handleCreateMatch(client, msg)
-	case "invite_friends":
-		s.handleInviteFriends(client, msg)
-	case "respond_invite":
-		s.handleRespondInvite(client, msg)
-	case "start_match":
-		s.handleStartMatch(client, msg)
-	case "leave_match":
-		s.handleLeaveMatch(client, msg)
-	default:
-		s.sendErrorToClient(client, "Unknown message type")
+	// auth_success까지는 JSON, 이후부터 협상된 인코딩 사용
+	if codec := client.negotiatedCodec(); codec != JSONCodec {
+		client.switchCodec(codec)
 	}
-}
 
-func (s *MatchServer) handleCreateMatch(client *Client, msg *SocketMessage) {
-	var req dto.CreateMatchRequest
-	if err := s.parseMessageData(msg.Data, &req); err != nil {
-		s.sendErrorToClient(client, "Invalid create match data")
-		return
-	}
+	client.authenticated = true
+	client.Conn.SetReadDeadline(time.Time{})
+	s.addClient(client)
+	s.refreshPresence(userID)
+	log.Printf("Client %s authenticated as user %s (%s, protocol v%d, build %s)", client.ID, client.UserID, client.Device, client.ProtocolVersion, client.ClientBuild)
+}
 
+func (s *MatchServer) handleCreateMatch(client *Client, msg *SocketMessage, req *dto.CreateMatchRequest) (*dto.CreateMatchResponse, error) {
 	// 서비스로 위임
-	matchInfo, err := s.matchService.CreateMatch(client.UserID, req.GameID, req.MaxPlayers)
+	matchInfo, err := s.matchService.CreateMatch(client.UserID, req.GameID, req.MaxPlayers, req.Public)
 	if err != nil {
-		s.sendErrorToClient(client, err.Error())
-		return
+		return nil, err
 	}
 
-	s.sendToClient(client, SocketMessage{
-		Type: "match_created",
-		Data: dto.CreateMatchResponse{
-			MatchID:    matchInfo.MatchID,
-			GameID:     matchInfo.GameID,
-			HostID:     matchInfo.HostID,
-			MaxPlayers: matchInfo.MaxPlayers,
-			Message:    "Match created successfully",
-		},
-	})
+	s.refreshPresence(client.UserID)
 
 	log.Printf("Match %s created by user %s for game %s", matchInfo.MatchID, client.UserID, matchInfo.GameID)
+	return &dto.CreateMatchResponse{
+		MatchID:    matchInfo.MatchID,
+		GameID:     matchInfo.GameID,
+		HostID:     matchInfo.HostID,
+		MaxPlayers: matchInfo.MaxPlayers,
+		Public:     matchInfo.Public,
+		Message:    "Match created successfully",
+	}, nil
 }
 
-func (s *MatchServer) handleInviteFriends(client *Client, msg *SocketMessage) {
-	var req dto.InviteFriendRequest
-	if err := s.parseMessageData(msg.Data, &req); err != nil {
-		s.sendErrorToClient(client, "Invalid invite data")
-		return
-	}
-
+func (s *MatchServer) handleInviteFriends(client *Client, msg *SocketMessage, req *dto.InviteFriendRequest) (*dto.InviteFriendResponse, error) {
 	// 서비스로 위임
 	response, err := s.matchService.InviteFriends(client.UserID, req.MatchID, req.FriendIds)
 	if err != nil {
-		s.sendErrorToClient(client, err.Error())
-		return
+		return nil, err
 	}
 
 	// 친구들에게 초대 알림 전송
 	for _, friendID := range response.InvitedIds {
-		if friendClient := s.getClientByUserID(friendID); friendClient != nil {
-			// 초대 정보 다시 조회해서 전송
-			inviteKey := fmt.Sprintf("invite:%s:%s", req.MatchID, friendID)
-			inviteData, err := database.Get(inviteKey)
-			if err == nil && inviteData != "" {
-				var invitation dto.MatchInvitation
-				if json.Unmarshal([]byte(inviteData), &invitation) == nil {
-					s.sendToClient(friendClient, SocketMessage{
-						Type: "match_invitation",
-						Data: invitation,
-					})
-				}
+		// 초대 정보 다시 조회해서 전송
+		inviteKey := fmt.Sprintf("invite:%s:%s", req.MatchID, friendID)
+		inviteData, err := database.Get(inviteKey)
+		if err == nil && inviteData != "" {
+			var invitation dto.MatchInvitation
+			if json.Unmarshal([]byte(inviteData), &invitation) == nil {
+				s.sendToUser(friendID, SocketMessage{
+					Type: "match_invitation",
+					Data: invitation,
+				}, "")
 			}
 		}
 	}
 
-	s.sendToClient(client, SocketMessage{
-		Type: "friends_invited",
-		Data: response,
-	})
-
 	log.Printf("User %s invited %d friends to match %s", client.UserID, len(response.InvitedIds), req.MatchID)
+	return response, nil
 }
 
-func (s *MatchServer) handleRespondInvite(client *Client, msg *SocketMessage) {
-	var req dto.InviteResponseRequest
-	if err := s.parseMessageData(msg.Data, &req); err != nil {
-		s.sendErrorToClient(client, "Invalid response data")
-		return
-	}
-
+func (s *MatchServer) handleRespondInvite(client *Client, msg *SocketMessage, req *dto.InviteResponseRequest) {
 	// 서비스로 위임
 	response, err := s.matchService.RespondInvite(client.UserID, req.MatchID, req.Response)
 	if err != nil {
-		s.sendErrorToClient(client, err.Error())
+		s.sendErrorToClient(client, msg, err)
 		return
 	}
 
@@ -261,71 +454,92 @@ func (s *MatchServer) handleRespondInvite(client *Client, msg *SocketMessage) {
 		msgType = "invite_accepted"
 	}
 
-	s.sendToClient(client, SocketMessage{
+	s.reply(client, msg, SocketMessage{
 		Type: msgType,
 		Data: response,
 	})
 
 	// accept인 경우 매치의 모든 플레이어에게 알림
 	if req.Response == "accept" {
-		players, err := s.matchService.GetMatchPlayers(req.MatchID)
-		if err == nil {
-			s.notifyMatchPlayers(req.MatchID, SocketMessage{
-				Type: "player_joined",
-				Data: map[string]interface{}{
-					"matchId": req.MatchID,
-					"userId":  client.UserID,
-					"players": players,
-				},
-			}, client.UserID)
-		}
+		s.notifyPlayerJoined(client, req.MatchID)
 	}
 
 	log.Printf("User %s %s invitation for match %s", client.UserID, req.Response, req.MatchID)
 }
 
-func (s *MatchServer) handleStartMatch(client *Client, msg *SocketMessage) {
-	var req dto.StartMatchRequest
-	if err := s.parseMessageData(msg.Data, &req); err != nil {
-		s.sendErrorToClient(client, "Invalid start match data")
-		return
+// handleListLobbies 공개 로비 목록 (차단 관계인 사용자의 로비는 제외)
+func (s *MatchServer) handleListLobbies(client *Client, msg *SocketMessage, req *dto.ListLobbiesRequest) (*dto.LobbyListResponse, error) {
+	lobbies, err := s.matchService.ListLobbies(client.UserID, req.GameID)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.LobbyListResponse{Lobbies: lobbies}, nil
+}
+
+func (s *MatchServer) handleJoinLobby(client *Client, msg *SocketMessage, req *dto.JoinLobbyRequest) (*dto.MatchInfo, error) {
+	matchInfo, err := s.matchService.JoinLobby(client.UserID, req.MatchID)
+	if err != nil {
+		return nil, err
 	}
 
+	s.notifyPlayerJoined(client, req.MatchID)
+
+	log.Printf("User %s joined public lobby %s", client.UserID, req.MatchID)
+	return matchInfo, nil
+}
+
+// notifyPlayerJoined 새 플레이어 참가를 매치의 다른 플레이어에게 알리고 로비 인원 갱신
+func (s *MatchServer) notifyPlayerJoined(client *Client, matchID string) {
+	players, err := s.matchService.GetMatchPlayers(matchID)
+	if err == nil {
+		s.notifyMatchPlayers(matchID, SocketMessage{
+			Type: "player_joined",
+			Data: map[string]interface{}{
+				"matchId": matchID,
+				"userId":  client.UserID,
+				"players": players,
+			},
+		}, client, "")
+	}
+	s.refreshMatchPresence(matchID)
+}
+
+func (s *MatchServer) handleStartMatch(client *Client, msg *SocketMessage, req *dto.StartMatchRequest) {
 	// 서비스로 위임
 	response, err := s.matchService.StartMatch(client.UserID, req.MatchID)
 	if err != nil {
-		s.sendErrorToClient(client, err.Error())
+		s.sendErrorToClient(client, msg, err)
 		return
 	}
 
-	// 모든 플레이어에게 게임 시작 알림
+	// 호스트에게는 응답으로, 나머지 플레이어에게는 이벤트로 게임 시작 알림
+	s.reply(client, msg, SocketMessage{
+		Type: "match_started",
+		Data: response,
+	})
 	s.notifyMatchPlayers(req.MatchID, SocketMessage{
 		Type: "match_started",
 		Data: response,
-	}, "")
+	}, client, "")
+
+	// 게임 세션 생성 및 시작
+	s.startGameSession(req.MatchID, response.Teams)
 
 	log.Printf("Match %s started by user %s", req.MatchID, client.UserID)
 }
 
-func (s *MatchServer) handleLeaveMatch(client *Client, msg *SocketMessage) {
+func (s *MatchServer) handleLeaveMatch(client *Client, msg *SocketMessage) (*dto.SuccessResponse, error) {
 	// 현재 매치 확인
 	matchID, err := database.HGet("user:matches", client.UserID)
 	if err != nil || matchID == "" {
-		s.sendErrorToClient(client, "You are not in any match")
-		return
+		return nil, errors.NotInMatch()
 	}
 
 	// 서비스로 위임
 	if err := s.matchService.LeaveMatch(client.UserID); err != nil {
-		s.sendErrorToClient(client, err.Error())
-		return
+		return nil, err
 	}
 
-	s.sendToClient(client, SocketMessage{
-		Type: "match_left",
-		Data: dto.SuccessResponse{Message: "Left the match"},
-	})
-
 	// 남은 플레이어들에게 알림
 	players, err := s.matchService.GetMatchPlayers(matchID)
 	if err == nil && len(players) > 0 {
@@ -336,64 +550,89 @@ func (s *MatchServer) handleLeaveMatch(client *Client, msg *SocketMessage) {
 				"userId":  client.UserID,
 				"players": players,
 			},
-		}, client.UserID)
+		}, client, "")
 	}
+	s.refreshPresence(client.UserID)
+	s.refreshMatchPresence(matchID)
 
 	log.Printf("User %s left match %s", client.UserID, matchID)
+	return &dto.SuccessResponse{Message: "Left the match"}, nil
 }
 
-func (s *MatchServer) notifyMatchPlayers(matchID string, msg SocketMessage, excludeUserID string) {
+// notifyMatchPlayers 매치 플레이어들의 세션으로 전송
+// 요청을 보낸 연결(exclude)만 빼고 같은 사용자의 다른 기기에도 전송, capability를 주면 그 기능이 있는 세션에만 전송
+func (s *MatchServer) notifyMatchPlayers(matchID string, msg SocketMessage, exclude *Client, capability string) {
 	players, err := s.matchService.GetMatchPlayers(matchID)
 	if err != nil {
 		return
 	}
 
 	for _, player := range players {
-		if player.UserID != excludeUserID {
-			if client := s.getClientByUserID(player.UserID); client != nil {
+		for _, client := range s.getClientsByUserID(player.UserID, capability) {
+			if client != exclude {
 				s.sendToClient(client, msg)
 			}
 		}
 	}
 }
 
-func (s *MatchServer) getClientByUserID(userID string) *Client {
-	socketID, err := database.HGet("user:sockets", userID)
-	if err != nil || socketID == "" {
-		return nil
-	}
-
-	s.clientsMux.RLock()
-	defer s.clientsMux.RUnlock()
-	return s.clients[socketID]
-}
-
 func (s *MatchServer) parseMessageData(data interface{}, target interface{}) error {
-	jsonData, err := json.Marshal(data)
-	if err != nil {
-		return err
-	}
-	return json.Unmarshal(jsonData, target)
+	return DecodePayload(data, target)
 }
 
 func (s *MatchServer) sendToClient(client *Client, msg SocketMessage) {
-	msgBytes, err := json.Marshal(msg)
+	if msg.Kind == "" {
+		msg.Kind = MESSAGE_KIND_EVENT
+	}
+
+	codec := client.currentCodec()
+	msgBytes, err := codec.Marshal(msg)
 	if err != nil {
 		log.Printf("Error marshaling message: %v", err)
 		return
 	}
+	s.writeToClient(client, codec, msgBytes)
+}
 
-	_, err = client.Conn.Write(msgBytes)
-	if err != nil {
+// writeToClient 인코딩된 메시지 전송 (바이너리 인코딩은 길이 헤더를 붙임)
+func (s *MatchServer) writeToClient(client *Client, codec Codec, msgBytes []byte) {
+	if codec != JSONCodec {
+		msgBytes = appendFrame(msgBytes)
+	}
+	if _, err := client.Conn.Write(msgBytes); err != nil {
 		log.Printf("Error sending message to client %s: %v", client.ID, err)
 	}
 }
 
-func (s *MatchServer) sendErrorToClient(client *Client, message string) {
-	s.sendToClient(client, SocketMessage{
+// sendErrorToClient 에러 코드가 담긴 error 프레임 전송 (요청 메시지의 ID를 그대로 돌려줌)
+// 실패한 요청은 응답을 저장하지 않아 같은 ID로 재시도 가능
+func (s *MatchServer) sendErrorToClient(client *Client, msg *SocketMessage, err error) {
+	appErr, ok := err.(*errors.AppError)
+	if !ok {
+		log.Printf("Unexpected error for client %s: %v", client.ID, err)
+		appErr = errors.InternalServerError()
+	}
+
+	frame := SocketMessage{
 		Type: "error",
-		Data: dto.ErrorResponse{Message: message},
-	})
+		Data: dto.ErrorResponse{
+			Code:    appErr.Code,
+			Message: appErr.Message,
+			Details: appErr.Details,
+		},
+	}
+
+	// 요청과 무관한 에러(토큰 만료 등)는 이벤트로 전송
+	if msg == nil {
+		s.sendToClient(client, frame)
+		return
+	}
+
+	frame.ID = msg.ID
+	frame.Kind = MESSAGE_KIND_REPLY
+	s.sendToClient(client, frame)
+	s.releaseRequest(client, msg)
+	msg.answered = true
 }
 
 func (s *MatchServer) addClient(client *Client) {
@@ -410,18 +649,48 @@ func (s *MatchServer) removeClient(clientID string) {
 	}
 	s.clientsMux.Unlock()
 
-	if exists && client.UserID != "" {
-		// Redis에서 매핑 제거
-		database.HDel("socket:users", clientID)
-		database.HDel("user:sockets", client.UserID)
+	if exists {
+		client.stopTokenTimers()
+		client.stopReplay()
+		s.unsubscribePresence(client, nil)
+	}
+
+	if !exists || client.UserID == "" {
+		log.Printf("Client %s disconnected", clientID)
+		return
+	}
+
+	// 이 연결을 가리키는 매핑만 정리 (이미 다른 연결로 재접속했다면 그 매핑은 유지)
+	remaining, err := s.sessionService.Close(client.UserID, clientID)
+	if err != nil {
+		log.Printf("Failed to release session %s for user %s: %v", clientID, client.UserID, err)
+	}
+	defer s.refreshPresence(client.UserID)
+
+	if client.handedOff.Load() {
+		// 서버 종료, 세션 교체: 매치/게임은 새 연결이 이어받음
+		log.Printf("Client %s (user: %s) handed off", clientID, client.UserID)
+		return
+	}
 
-		// 매치에서 제거
-		if err := s.matchService.LeaveMatch(client.UserID); err == nil {
-			log.Printf("Removed user %s from match due to disconnect", client.UserID)
+	if remaining > 0 {
+		// 다른 기기가 남아 있으면 매치는 유지하고, 플레이 가능한 기기가 없을 때만 게임에서 제외
+		if canPlay, err := s.sessionService.HasCapability(client.UserID, service.SESSION_CAPABILITY_PLAY); err == nil && !canPlay {
+			s.disconnectFromGame(client.UserID)
 		}
+		log.Printf("Client %s (user: %s) disconnected, %d sessions remaining", clientID, client.UserID, remaining)
+		return
+	}
 
-		log.Printf("Client %s (user: %s) disconnected", clientID, client.UserID)
-	} else {
-		log.Printf("Client %s disconnected", clientID)
+	// 진행 중인 게임에서 제외
+	s.disconnectFromGame(client.UserID)
+
+	// 매치에서 제거 (남은 플레이어들의 로비 인원 갱신)
+	matchID, _ := database.HGet("user:matches", client.UserID)
+	if err := s.matchService.LeaveMatch(client.UserID); err == nil {
+		log.Printf("Removed user %s from match due to disconnect", client.UserID)
+		s.refreshMatchPresence(matchID)
 	}
+
+	log.Printf("Client %s (user: %s) disconnected", clientID, client.UserID)
 }
@@ -0,0 +1,95 @@
+package socket
+
+import (
+	"expvar"
+	"game-server/internal/pkg/errors"
+	"log"
+	"runtime/debug"
+	"slices"
+	"time"
+)
+
+var (
+	// /admin/metrics 로 노출되는 소켓 메시지 처리 지표 (메시지 타입별)
+	messageCount    = expvar.NewMap("socket_messages_total")
+	messageDuration = expvar.NewMap("socket_message_duration_us_total")
+	messagePanics   = expvar.NewMap("socket_message_panics_total")
+	messageLimited  = expvar.NewMap("socket_messages_rate_limited_total")
+)
+
+// loggingMiddleware 메시지 타입, 사용자, 처리 시간 기록
+func loggingMiddleware(next MessageHandler) MessageHandler {
+	return func(ctx *MessageContext) {
+		started := time.Now()
+		next(ctx)
+
+		if !ctx.Route.Quiet {
+			log.Printf("Handled %s (id=%s) from user %s in %s", ctx.Message.Type, ctx.Message.ID, ctx.Client.UserID, time.Since(started))
+		}
+	}
+}
+
+// metricsMiddleware 메시지 타입별 처리 횟수와 누적 처리 시간 집계
+func metricsMiddleware(next MessageHandler) MessageHandler {
+	return func(ctx *MessageContext) {
+		started := time.Now()
+		defer func() {
+			messageCount.Add(ctx.Route.Type, 1)
+			messageDuration.Add(ctx.Route.Type, time.Since(started).Microseconds())
+		}()
+		next(ctx)
+	}
+}
+
+// authorizeMiddleware 라우트에 필요한 역할이 토큰에 없거나 기기에 필요한 기능이 없으면 거부
+func (s *MatchServer) authorizeMiddleware(next MessageHandler) MessageHandler {
+	return func(ctx *MessageContext) {
+		if len(ctx.Route.Roles) > 0 {
+			claims := ctx.Client.currentClaims()
+			if claims == nil || !claims.HasAnyRole(ctx.Route.Roles...) {
+				s.sendErrorToClient(ctx.Client, ctx.Message, errors.Forbidden())
+				return
+			}
+		}
+		if capability := ctx.Route.Capability; capability != "" && !slices.Contains(ctx.Client.DeviceCapabilities, capability) {
+			s.sendErrorToClient(ctx.Client, ctx.Message, errors.DeviceCapabilityRequired(capability))
+			return
+		}
+		next(ctx)
+	}
+}
+
+// recoveryMiddleware 핸들러 패닉이 연결이나 프로세스 전체로 번지지 않도록 복구
+func (s *MatchServer) recoveryMiddleware(next MessageHandler) MessageHandler {
+	return func(ctx *MessageContext) {
+		defer s.recoverPanic(ctx.Client, ctx.Message)
+		next(ctx)
+	}
+}
+
+// recoverPanic 패닉을 복구하고 INTERNAL_ERROR 전송 후 연결 종료 (반드시 defer로 호출)
+// 핸들러는 recoveryMiddleware, 핸들러 밖의 연결 고루틴(읽기 루프, 타이머, 리플레이)은 직접 defer로 사용
+// 타이머/리플레이 고루틴은 읽기 루프의 정리를 거치지 않으므로 여기서 바로 removeClient로 등록 해제
+func (s *MatchServer) recoverPanic(client *Client, msg *SocketMessage) {
+	recovered := recover()
+	if recovered == nil {
+		return
+	}
+
+	msgType, msgID := "-", "-"
+	if msg != nil {
+		msgType, msgID = msg.Type, msg.ID
+	}
+	messagePanics.Add(msgType, 1)
+	log.Printf("Panic on client %s (user %s) while handling %s (id=%s): %v\n%s", client.ID, client.UserID, msgType, msgID, recovered, debug.Stack())
+
+	// 에러 프레임 전송 중 다시 패닉이 나도 연결은 반드시 닫고 등록 해제
+	defer s.removeClient(client.ID)
+	defer client.Conn.Close()
+	defer func() {
+		if err := recover(); err != nil {
+			log.Printf("Failed to send internal error to client %s: %v", client.ID, err)
+		}
+	}()
+	s.sendErrorToClient(client, msg, errors.InternalError())
+}
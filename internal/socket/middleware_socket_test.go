@@ -0,0 +1,195 @@
+package socket
+
+import (
+	"encoding/json"
+	"game-server/internal/domain"
+	"game-server/internal/dto"
+	"game-server/internal/pkg/auth"
+	"game-server/internal/pkg/database/redistest"
+	"game-server/internal/service"
+	"io"
+	"net"
+	"testing"
+	"time"
+)
+
+// pipeClient 서버 쪽 연결을 가진 Client와 클라이언트 쪽 연결
+func pipeClient(t *testing.T) (*Client, net.Conn) {
+	t.Helper()
+	server, peer := net.Pipe()
+	t.Cleanup(func() {
+		server.Close()
+		peer.Close()
+	})
+	return &Client{ID: "client-1", UserID: "user-1", Conn: server, codec: JSONCodec, readCodec: JSONCodec}, peer
+}
+
+func TestRecoveryMiddleware(t *testing.T) {
+	s := &MatchServer{}
+	registry := NewMessageRegistry()
+	registry.Use(s.recoveryMiddleware)
+	registry.Register(RouteNoData("boom", func(client *Client, msg *SocketMessage) {
+		var data map[string]interface{}
+		data["token"] = "nil map" // 잘못된 요청에서 나는 패닉 재현
+	}))
+	route, _ := registry.lookup("boom")
+
+	client, peer := pipeClient(t)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		route.handler(&MessageContext{Client: client, Message: &SocketMessage{Type: "boom"}, Route: route})
+	}()
+
+	peer.SetReadDeadline(time.Now().Add(time.Second))
+	buffer := make([]byte, 4096)
+	n, err := peer.Read(buffer)
+	if err != nil {
+		t.Fatalf("expected an error frame: %v", err)
+	}
+	var frame struct {
+		Type string            `json:"type"`
+		Data dto.ErrorResponse `json:"data"`
+	}
+	if err := json.Unmarshal(buffer[:n], &frame); err != nil {
+		t.Fatal(err)
+	}
+	if frame.Type != "error" || frame.Data.Code != "INTERNAL_ERROR" {
+		t.Fatalf("frame = %+v", frame)
+	}
+
+	// 패닉이 난 연결은 닫힘
+	if _, err := peer.Read(buffer); err != io.EOF {
+		t.Fatalf("connection should be closed, read error = %v", err)
+	}
+	<-done
+}
+
+func TestRecoverPanicUnregistersClient(t *testing.T) {
+	redistest.Start(t)
+	sessions := service.NewSessionService(service.SESSION_POLICY_MULTI)
+	games := service.NewGameService(nil)
+	matches := service.NewMatchService(sessions, service.NewFriendService())
+	s := &MatchServer{
+		clients:         make(map[string]*Client),
+		sessionService:  sessions,
+		gameService:     games,
+		matchService:    matches,
+		presenceService: service.NewPresenceService(sessions, matches, games),
+	}
+	sessions.Heartbeat()
+
+	client, peer := pipeClient(t)
+	go io.Copy(io.Discard, peer)
+	if err := sessions.Open(client.UserID, &dto.SessionInfo{SocketID: client.ID, Device: service.DEVICE_PC, ConnectedAt: time.Now()}); err != nil {
+		t.Fatal(err)
+	}
+	s.addClient(client)
+
+	// 읽기 루프 밖의 고루틴(토큰 만료 타이머, 리플레이)에서 난 패닉
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		defer s.recoverPanic(client, nil)
+		var replay *replayPlayer
+		_ = replay.replay.GameID
+	}()
+	<-done
+
+	s.clientsMux.RLock()
+	_, registered := s.clients[client.ID]
+	s.clientsMux.RUnlock()
+	if registered {
+		t.Fatal("client should be unregistered after a panic")
+	}
+	if online, _ := sessions.IsOnline(client.UserID); online {
+		t.Fatal("session of the panicked connection should be closed")
+	}
+}
+
+func TestAuthorizeMiddlewareRequiresDeviceCapability(t *testing.T) {
+	s := &MatchServer{}
+	registry := NewMessageRegistry()
+	registry.Use(s.authorizeMiddleware)
+	handled := make(chan struct{}, 1)
+	gameEvent := RouteNoData("game_event", func(client *Client, msg *SocketMessage) {
+		handled <- struct{}{}
+	})
+	gameEvent.Capability = service.SESSION_CAPABILITY_PLAY
+	registry.Register(gameEvent)
+	route, _ := registry.lookup("game_event")
+
+	// 모바일 앱(can_chat만 있음)은 거부
+	client, peer := pipeClient(t)
+	client.DeviceCapabilities = []string{service.SESSION_CAPABILITY_CHAT}
+	go route.handler(&MessageContext{Client: client, Message: &SocketMessage{Type: "game_event"}, Route: route})
+
+	peer.SetReadDeadline(time.Now().Add(time.Second))
+	buffer := make([]byte, 4096)
+	n, err := peer.Read(buffer)
+	if err != nil {
+		t.Fatalf("expected an error frame: %v", err)
+	}
+	var frame struct {
+		Data dto.ErrorResponse `json:"data"`
+	}
+	if err := json.Unmarshal(buffer[:n], &frame); err != nil {
+		t.Fatal(err)
+	}
+	if frame.Data.Code != "DEVICE_CAPABILITY_REQUIRED" {
+		t.Fatalf("frame = %+v", frame)
+	}
+	if len(handled) != 0 {
+		t.Fatal("handler should not run for a device without can_play")
+	}
+
+	// PC는 허용
+	client.DeviceCapabilities = []string{service.SESSION_CAPABILITY_PLAY, service.SESSION_CAPABILITY_CHAT}
+	route.handler(&MessageContext{Client: client, Message: &SocketMessage{Type: "game_event"}, Route: route})
+	if len(handled) != 1 {
+		t.Fatal("handler should run for a device with can_play")
+	}
+}
+
+func TestGameplayRoutesRequirePlay(t *testing.T) {
+	s := &MatchServer{registry: NewMessageRegistry()}
+	s.registerRoutes()
+
+	for _, msgType := range []string{"create_match", "respond_invite", "join_lobby", "start_match", "game_event"} {
+		route, ok := s.registry.lookup(msgType)
+		if !ok || route.Capability != service.SESSION_CAPABILITY_PLAY {
+			t.Errorf("%s should require %s", msgType, service.SESSION_CAPABILITY_PLAY)
+		}
+	}
+	for _, msgType := range []string{"invite_friends", "leave_match", "list_friends"} {
+		if route, _ := s.registry.lookup(msgType); route.Capability != "" {
+			t.Errorf("%s should be allowed on every device", msgType)
+		}
+	}
+}
+
+func TestCanWatchReplay(t *testing.T) {
+	replay := &service.Replay{
+		GameID: "game-1",
+		Events: []domain.GameEvent{{
+			Type: service.GAME_EVENT_STARTED,
+			Data: dto.GameInfo{ID: "game-1", Players: []dto.MatchPlayer{{UserID: "player"}}},
+		}},
+	}
+
+	cases := []struct {
+		name   string
+		client *Client
+		want   bool
+	}{
+		{"participant", &Client{UserID: "player", Claims: &auth.Claims{UserID: "player"}}, true},
+		{"outsider", &Client{UserID: "outsider", Claims: &auth.Claims{UserID: "outsider"}}, false},
+		{"admin", &Client{UserID: "admin", Claims: &auth.Claims{UserID: "admin", Roles: []string{auth.ROLE_ADMIN}}}, true},
+		{"analyst", &Client{UserID: "analyst", Claims: &auth.Claims{UserID: "analyst", Roles: []string{auth.ROLE_ANALYST}}}, true},
+	}
+	for _, tc := range cases {
+		if got := canWatchReplay(tc.client, replay); got != tc.want {
+			t.Errorf("%s: canWatchReplay = %v, want %v", tc.name, got, tc.want)
+		}
+	}
+}
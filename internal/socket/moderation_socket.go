@@ -0,0 +1,82 @@
+package socket
+
+import (
+	"encoding/json"
+	"game-server/internal/dto"
+	"game-server/internal/pkg/database"
+	"game-server/internal/pkg/errors"
+	"game-server/internal/service"
+	"log"
+)
+
+// watchBans 다른 노드에서 발생한 차단도 받아서 이 노드의 소켓을 즉시 끊음
+func (s *MatchServer) watchBans() {
+	pubsub := database.Subscribe(service.BAN_CHANNEL)
+	defer pubsub.Close()
+
+	for message := range pubsub.Channel() {
+		var ban dto.BanInfo
+		if err := json.Unmarshal([]byte(message.Payload), &ban); err != nil {
+			log.Printf("Invalid ban message: %v", err)
+			continue
+		}
+		s.kickBannedUser(&ban)
+	}
+}
+
+// watchRevocations 폐기된 토큰/세션으로 인증한 소켓을 즉시 끊음
+func (s *MatchServer) watchRevocations() {
+	pubsub := database.Subscribe(service.REVOCATION_CHANNEL)
+	defer pubsub.Close()
+
+	for message := range pubsub.Channel() {
+		var revocation dto.RevokeTokenRequest
+		if err := json.Unmarshal([]byte(message.Payload), &revocation); err != nil {
+			log.Printf("Invalid revocation message: %v", err)
+			continue
+		}
+		s.kickRevokedClients(&revocation)
+	}
+}
+
+func (s *MatchServer) kickRevokedClients(revocation *dto.RevokeTokenRequest) {
+	s.clientsMux.RLock()
+	var targets []*Client
+	for _, client := range s.clients {
+		claims := client.currentClaims()
+		if claims == nil {
+			continue
+		}
+		if (revocation.JTI != "" && claims.ID == revocation.JTI) ||
+			(revocation.SessionID != "" && claims.SessionID == revocation.SessionID) {
+			targets = append(targets, client)
+		}
+	}
+	s.clientsMux.RUnlock()
+
+	for _, client := range targets {
+		s.sendErrorToClient(client, nil, errors.TokenRevoked())
+		client.Conn.Close()
+		log.Printf("Disconnected client %s (user %s): token revoked", client.ID, client.UserID)
+	}
+}
+
+func (s *MatchServer) kickBannedUser(ban *dto.BanInfo) {
+	s.clientsMux.RLock()
+	var targets []*Client
+	for _, client := range s.clients {
+		if client.UserID == ban.UserID {
+			targets = append(targets, client)
+		}
+	}
+	s.clientsMux.RUnlock()
+
+	for _, client := range targets {
+		s.sendToClient(client, SocketMessage{
+			Type: "banned",
+			Data: ban,
+		})
+		client.Conn.Close()
+		log.Printf("Disconnected banned user %s (client %s)", ban.UserID, client.ID)
+	}
+}
@@ -0,0 +1,178 @@
+package socket
+
+import (
+	"encoding/json"
+	"fmt"
+	"game-server/internal/dto"
+	"game-server/internal/pkg/database"
+	"game-server/internal/pkg/errors"
+	"game-server/internal/service"
+	"log"
+	"slices"
+)
+
+const (
+	// MAX_PRESENCE_SUBSCRIPTIONS 연결 하나가 구독할 수 있는 최대 사용자 수
+	MAX_PRESENCE_SUBSCRIPTIONS = 500
+)
+
+// watchPresence 모든 노드에서 발행한 접속 상태 변경을 이 노드의 구독자에게 전달
+func (s *MatchServer) watchPresence() {
+	pubsub := database.Subscribe(service.PRESENCE_CHANNEL)
+	defer pubsub.Close()
+
+	for message := range pubsub.Channel() {
+		var presence dto.Presence
+		if err := json.Unmarshal([]byte(message.Payload), &presence); err != nil {
+			log.Printf("Invalid presence message: %v", err)
+			continue
+		}
+
+		for _, client := range s.presenceSubscribers(presence.UserID) {
+			s.sendToClient(client, SocketMessage{
+				Type: "presence_changed",
+				Data: presence,
+			})
+		}
+	}
+}
+
+// handleSubscribePresence 친구의 접속 상태 구독
+// 수락된 친구이면서 어느 쪽도 차단하지 않은 사용자만 구독하고 나머지는 rejected로 알려줌
+func (s *MatchServer) handleSubscribePresence(client *Client, msg *SocketMessage, req *dto.SubscribePresenceRequest) (*dto.SubscribePresenceResponse, error) {
+	if len(req.UserIDs) == 0 {
+		return nil, errors.BadRequestWithMessage("userIds가 필요합니다")
+	}
+	if len(req.UserIDs) > MAX_PRESENCE_SUBSCRIPTIONS {
+		return nil, errors.BadRequestWithMessage(fmt.Sprintf("접속 상태는 최대 %d명까지 구독할 수 있습니다", MAX_PRESENCE_SUBSCRIPTIONS))
+	}
+
+	friendIDs, err := s.friendService.FilterFriends(client.UserID, req.UserIDs)
+	if err != nil {
+		return nil, errors.DBError()
+	}
+
+	if len(friendIDs) > 0 {
+		if err := s.subscribePresence(client, friendIDs); err != nil {
+			return nil, err
+		}
+	}
+
+	var rejected []string
+	for _, userID := range req.UserIDs {
+		if !slices.Contains(friendIDs, userID) && !slices.Contains(rejected, userID) {
+			rejected = append(rejected, userID)
+		}
+	}
+
+	return &dto.SubscribePresenceResponse{
+		Presences: s.presenceService.GetMany(friendIDs),
+		Rejected:  rejected,
+	}, nil
+}
+
+func (s *MatchServer) handleUnsubscribePresence(client *Client, msg *SocketMessage, req *dto.UnsubscribePresenceRequest) {
+	s.unsubscribePresence(client, req.UserIDs)
+	s.ack(client, msg)
+}
+
+func (s *MatchServer) handleSetPresence(client *Client, msg *SocketMessage, req *dto.SetPresenceRequest) (*dto.Presence, error) {
+	return s.presenceService.SetAway(client.UserID, req.Away)
+}
+
+// subscribePresence 구독 추가 (기존 구독은 유지)
+func (s *MatchServer) subscribePresence(client *Client, userIDs []string) *errors.AppError {
+	s.presenceMux.Lock()
+	defer s.presenceMux.Unlock()
+
+	if client.presenceWatching == nil {
+		client.presenceWatching = make(map[string]struct{})
+	}
+
+	added := 0
+	for _, userID := range userIDs {
+		if _, ok := client.presenceWatching[userID]; !ok {
+			added++
+		}
+	}
+	if len(client.presenceWatching)+added > MAX_PRESENCE_SUBSCRIPTIONS {
+		return errors.BadRequestWithMessage(fmt.Sprintf("접속 상태는 최대 %d명까지 구독할 수 있습니다", MAX_PRESENCE_SUBSCRIPTIONS))
+	}
+
+	for _, userID := range userIDs {
+		client.presenceWatching[userID] = struct{}{}
+		if s.presenceSubs[userID] == nil {
+			s.presenceSubs[userID] = make(map[*Client]struct{})
+		}
+		s.presenceSubs[userID][client] = struct{}{}
+	}
+	return nil
+}
+
+// unsubscribePresence 구독 해제 (userIDs가 비어 있으면 전체 해제)
+func (s *MatchServer) unsubscribePresence(client *Client, userIDs []string) {
+	s.presenceMux.Lock()
+	defer s.presenceMux.Unlock()
+
+	if len(userIDs) == 0 {
+		for userID := range client.presenceWatching {
+			userIDs = append(userIDs, userID)
+		}
+	}
+
+	for _, userID := range userIDs {
+		delete(client.presenceWatching, userID)
+		if subscribers, ok := s.presenceSubs[userID]; ok {
+			delete(subscribers, client)
+			if len(subscribers) == 0 {
+				delete(s.presenceSubs, userID)
+			}
+		}
+	}
+}
+
+// dropPresencePair 두 사용자가 더 이상 친구가 아니면 이 노드에서 서로에 대한 구독 해제
+func (s *MatchServer) dropPresencePair(userID, otherID string) {
+	for _, pair := range [][2]string{{userID, otherID}, {otherID, userID}} {
+		for _, client := range s.presenceSubscribers(pair[1]) {
+			if client.UserID == pair[0] {
+				s.unsubscribePresence(client, []string{pair[1]})
+			}
+		}
+	}
+}
+
+func (s *MatchServer) presenceSubscribers(userID string) []*Client {
+	s.presenceMux.RLock()
+	defer s.presenceMux.RUnlock()
+
+	clients := make([]*Client, 0, len(s.presenceSubs[userID]))
+	for client := range s.presenceSubs[userID] {
+		clients = append(clients, client)
+	}
+	return clients
+}
+
+// refreshPresence 사용자의 접속 상태를 다시 계산해서 바뀌었으면 구독자에게 알림
+func (s *MatchServer) refreshPresence(userID string) {
+	if _, err := s.presenceService.Refresh(userID); err != nil {
+		log.Printf("Failed to refresh presence for user %s: %v", userID, err)
+	}
+}
+
+// refreshMatchPresence 매치 인원이 바뀌면 로비에 있는 모든 플레이어의 상태 갱신
+func (s *MatchServer) refreshMatchPresence(matchID string) {
+	players, err := s.matchService.GetMatchPlayers(matchID)
+	if err != nil {
+		return
+	}
+	for _, player := range players {
+		s.refreshPresence(player.UserID)
+	}
+}
+
+func (s *MatchServer) refreshGamePresence(game *service.Game) {
+	for _, player := range game.Players {
+		s.refreshPresence(player.UserID)
+	}
+}
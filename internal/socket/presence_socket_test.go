@@ -0,0 +1,39 @@
+package socket
+
+import "testing"
+
+func TestDropPresencePair(t *testing.T) {
+	s := &MatchServer{presenceSubs: make(map[string]map[*Client]struct{})}
+	alice := &Client{ID: "a1", UserID: "alice"}
+	aliceMobile := &Client{ID: "a2", UserID: "alice"}
+	bob := &Client{ID: "b1", UserID: "bob"}
+	carol := &Client{ID: "c1", UserID: "carol"}
+
+	s.subscribePresence(alice, []string{"bob", "carol"})
+	s.subscribePresence(aliceMobile, []string{"bob"})
+	s.subscribePresence(bob, []string{"alice", "carol"})
+	s.subscribePresence(carol, []string{"bob"})
+
+	s.dropPresencePair("bob", "alice")
+
+	for _, client := range []*Client{alice, aliceMobile} {
+		if _, ok := client.presenceWatching["bob"]; ok {
+			t.Fatalf("%s should no longer watch bob", client.ID)
+		}
+	}
+	if _, ok := bob.presenceWatching["alice"]; ok {
+		t.Fatal("bob should no longer watch alice")
+	}
+
+	// 다른 친구에 대한 구독은 유지
+	if _, ok := alice.presenceWatching["carol"]; !ok {
+		t.Fatal("alice should still watch carol")
+	}
+	subscribers := s.presenceSubscribers("bob")
+	if len(subscribers) != 1 || subscribers[0] != carol {
+		t.Fatalf("bob subscribers = %v, want only carol", subscribers)
+	}
+	if len(s.presenceSubscribers("alice")) != 0 {
+		t.Fatal("alice should have no subscribers")
+	}
+}
@@ -0,0 +1,106 @@
+package socket
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"io"
+	"net"
+	"strings"
+	"testing"
+)
+
+func proxyReader(data []byte) *bufio.Reader {
+	return bufio.NewReader(bytes.NewReader(data))
+}
+
+func TestReadProxyHeaderV1(t *testing.T) {
+	reader := proxyReader([]byte("PROXY TCP4 203.0.113.7 10.0.0.1 51234 9000\r\n{\"type\":\"auth\"}"))
+	addr, err := readProxyHeader(reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if addr.String() != "203.0.113.7:51234" {
+		t.Fatalf("addr = %s", addr)
+	}
+	// 헤더 뒤의 데이터는 그대로 남아 있어야 함
+	rest, _ := io.ReadAll(reader)
+	if string(rest) != `{"type":"auth"}` {
+		t.Fatalf("remaining = %q", rest)
+	}
+
+	addr, err = readProxyHeader(proxyReader([]byte("PROXY TCP6 2001:db8::1 2001:db8::2 443 9000\r\n")))
+	if err != nil || addr.String() != "[2001:db8::1]:443" {
+		t.Fatalf("TCP6 addr = %v, err = %v", addr, err)
+	}
+
+	addr, err = readProxyHeader(proxyReader([]byte("PROXY UNKNOWN\r\n")))
+	if err != nil || addr != nil {
+		t.Fatalf("UNKNOWN = (%v, %v), want (nil, nil)", addr, err)
+	}
+}
+
+func TestReadProxyHeaderV1Invalid(t *testing.T) {
+	cases := []string{
+		"GET / HTTP/1.1\r\n\r\n",
+		"PROXY TCP4 203.0.113.7 10.0.0.1 51234\r\n",
+		"PROXY TCP4 2001:db8::1 10.0.0.1 51234 9000\r\n",
+		"PROXY TCP4 203.0.113.7 10.0.0.1 99999 9000\r\n",
+		"PROXY UDP4 203.0.113.7 10.0.0.1 51234 9000\r\n",
+		"PROXY TCP4 203.0.113.7 10.0.0.1 51234 9000\n",
+		"PROXY TCP4 " + strings.Repeat("1", 120) + "\r\n",
+	}
+	for _, header := range cases {
+		if _, err := readProxyHeader(proxyReader([]byte(header))); err == nil {
+			t.Errorf("%q should be rejected", header)
+		}
+	}
+}
+
+func proxyV2Header(command byte, family byte, addresses []byte) []byte {
+	header := append([]byte{}, proxyV2Signature...)
+	header = append(header, 0x20|command, family)
+	header = binary.BigEndian.AppendUint16(header, uint16(len(addresses)))
+	return append(header, addresses...)
+}
+
+func TestReadProxyHeaderV2(t *testing.T) {
+	ipv4 := []byte{203, 0, 113, 7, 10, 0, 0, 1}
+	ipv4 = binary.BigEndian.AppendUint16(ipv4, 51234)
+	ipv4 = binary.BigEndian.AppendUint16(ipv4, 9000)
+
+	addr, err := readProxyHeader(proxyReader(proxyV2Header(0x1, 0x11, ipv4)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if addr.String() != "203.0.113.7:51234" {
+		t.Fatalf("addr = %s", addr)
+	}
+
+	ipv6 := append(net.ParseIP("2001:db8::1").To16(), net.ParseIP("2001:db8::2").To16()...)
+	ipv6 = binary.BigEndian.AppendUint16(ipv6, 443)
+	ipv6 = binary.BigEndian.AppendUint16(ipv6, 9000)
+	addr, err = readProxyHeader(proxyReader(proxyV2Header(0x1, 0x21, ipv6)))
+	if err != nil || addr.String() != "[2001:db8::1]:443" {
+		t.Fatalf("IPv6 addr = %v, err = %v", addr, err)
+	}
+
+	// LOCAL (헬스체크)는 주소 없음
+	addr, err = readProxyHeader(proxyReader(proxyV2Header(0x0, 0x11, ipv4)))
+	if err != nil || addr != nil {
+		t.Fatalf("LOCAL = (%v, %v), want (nil, nil)", addr, err)
+	}
+
+	// 주소 블록이 짧으면 거절
+	if _, err := readProxyHeader(proxyReader(proxyV2Header(0x1, 0x11, ipv4[:8]))); err == nil {
+		t.Fatal("short IPv4 address block should be rejected")
+	}
+	// 지원하지 않는 명령
+	if _, err := readProxyHeader(proxyReader(proxyV2Header(0x2, 0x11, ipv4))); err == nil {
+		t.Fatal("unknown command should be rejected")
+	}
+	// 헤더가 잘림
+	if _, err := readProxyHeader(proxyReader(proxyV2Header(0x1, 0x11, ipv4)[:20])); err == nil {
+		t.Fatal("truncated header should be rejected")
+	}
+}
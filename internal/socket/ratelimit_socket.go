@@ -0,0 +1,135 @@
+package socket
+
+import (
+	"fmt"
+	"game-server/internal/pkg/database"
+	"game-server/internal/pkg/errors"
+	"log"
+	"math"
+	"sync"
+	"time"
+)
+
+// RateLimit 토큰 버킷 설정 (초당 Rate개 충전, 최대 Burst개)
+type RateLimit struct {
+	Rate  float64
+	Burst int
+}
+
+func (l RateLimit) IsZero() bool {
+	return l.Rate <= 0 || l.Burst <= 0
+}
+
+// rateLimitMiddleware 라우트에 설정된 사용자별 처리 속도 제한 적용
+// 버킷은 Redis에 있어서 같은 사용자가 여러 노드에 접속해도 제한이 합산됨
+func (s *MatchServer) rateLimitMiddleware(next MessageHandler) MessageHandler {
+	return func(ctx *MessageContext) {
+		limit := ctx.Route.RateLimit
+		if !limit.IsZero() && ctx.Client.authenticated {
+			key := fmt.Sprintf("ratelimit:user:%s:%s", ctx.Client.UserID, ctx.Route.Type)
+			if ok, retryAfter := s.takeToken(ctx.Client, key, ctx.Route.Type, limit); !ok {
+				s.rejectRateLimited(ctx.Client, ctx.Message, ctx.Route.Type, retryAfter)
+				return
+			}
+		}
+		next(ctx)
+	}
+}
+
+// checkUnauthenticatedLimit 인증 전 연결은 사용자를 모르므로 IP별로 제한
+func (s *MatchServer) checkUnauthenticatedLimit(client *Client, msg *SocketMessage) bool {
+	if client.authenticated || s.unauthRateLimit.IsZero() {
+		return true
+	}
+
+	key := fmt.Sprintf("ratelimit:ip:%s", client.IP)
+	if ok, retryAfter := s.takeToken(client, key, "unauthenticated", s.unauthRateLimit); !ok {
+		s.rejectRateLimited(client, msg, "unauthenticated", retryAfter)
+		return false
+	}
+	return true
+}
+
+// takeToken Redis 토큰 버킷 사용, Redis 장애 시에는 연결 단위 버킷으로 대신 제한
+func (s *MatchServer) takeToken(client *Client, key string, name string, limit RateLimit) (bool, time.Duration) {
+	ok, retryAfter, err := database.TakeToken(key, limit.Rate, limit.Burst)
+	if err == nil {
+		return ok, retryAfter
+	}
+
+	log.Printf("Failed to check rate limit %s, using local limit: %v", key, err)
+	return client.limiter(name, limit).take()
+}
+
+// rejectRateLimited RATE_LIMITED 에러 전송, 위반이 반복되면 연결 종료
+func (s *MatchServer) rejectRateLimited(client *Client, msg *SocketMessage, name string, retryAfter time.Duration) {
+	messageLimited.Add(name, 1)
+	s.sendErrorToClient(client, msg, errors.RateLimited(retryAfter))
+
+	violations := client.recordViolation(s.violationWindow)
+	if s.maxViolations > 0 && violations >= s.maxViolations {
+		log.Printf("Disconnecting client %s (user %s, ip %s): %d rate limit violations within %s", client.ID, client.UserID, client.IP, violations, s.violationWindow)
+		client.Conn.Close()
+	}
+}
+
+type tokenBucket struct {
+	limit    RateLimit
+	mu       sync.Mutex
+	tokens   float64
+	lastFill time.Time
+}
+
+func newTokenBucket(limit RateLimit) *tokenBucket {
+	return &tokenBucket{
+		limit:    limit,
+		tokens:   float64(limit.Burst),
+		lastFill: time.Now(),
+	}
+}
+
+// take 토큰 하나 사용, 부족하면 다음 토큰이 생길 때까지의 시간 반환
+func (b *tokenBucket) take() (bool, time.Duration) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+	b.tokens = math.Min(float64(b.limit.Burst), b.tokens+now.Sub(b.lastFill).Seconds()*b.limit.Rate)
+	b.lastFill = now
+
+	if b.tokens >= 1 {
+		b.tokens--
+		return true, 0
+	}
+	return false, time.Duration((1 - b.tokens) / b.limit.Rate * float64(time.Second))
+}
+
+// limiter 연결 단위 토큰 버킷 (처음 사용할 때 생성)
+func (c *Client) limiter(name string, limit RateLimit) *tokenBucket {
+	c.limitersMux.Lock()
+	defer c.limitersMux.Unlock()
+
+	if c.limiters == nil {
+		c.limiters = make(map[string]*tokenBucket)
+	}
+	bucket, ok := c.limiters[name]
+	if !ok {
+		bucket = newTokenBucket(limit)
+		c.limiters[name] = bucket
+	}
+	return bucket
+}
+
+// recordViolation 제한 위반 기록 후 window 안의 위반 횟수 반환
+func (c *Client) recordViolation(window time.Duration) int {
+	c.limitersMux.Lock()
+	defer c.limitersMux.Unlock()
+
+	now := time.Now()
+	if now.Sub(c.violationsSince) > window {
+		c.violations = 0
+		c.violationsSince = now
+	}
+	c.violations++
+	return c.violations
+}
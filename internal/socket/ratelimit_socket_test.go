@@ -0,0 +1,118 @@
+package socket
+
+import (
+	"game-server/internal/config"
+	"game-server/internal/pkg/database"
+	"io"
+	"testing"
+	"time"
+)
+
+func TestTokenBucketTake(t *testing.T) {
+	bucket := newTokenBucket(RateLimit{Rate: 2, Burst: 3})
+
+	for i := 0; i < 3; i++ {
+		if ok, _ := bucket.take(); !ok {
+			t.Fatalf("token %d should be allowed within burst", i+1)
+		}
+	}
+
+	ok, retryAfter := bucket.take()
+	if ok {
+		t.Fatal("token beyond burst should be rejected")
+	}
+	if retryAfter <= 0 || retryAfter > 500*time.Millisecond {
+		t.Fatalf("retryAfter = %s, want (0, 500ms]", retryAfter)
+	}
+
+	// 0.5초가 지난 것처럼 만들면 토큰 하나 충전
+	bucket.lastFill = bucket.lastFill.Add(-500 * time.Millisecond)
+	if ok, _ := bucket.take(); !ok {
+		t.Fatal("token should be refilled after 500ms")
+	}
+
+	// 오래 쉬어도 burst 이상은 쌓이지 않음
+	bucket.lastFill = bucket.lastFill.Add(-time.Hour)
+	for i := 0; i < 3; i++ {
+		if ok, _ := bucket.take(); !ok {
+			t.Fatalf("token %d should be allowed after refill", i+1)
+		}
+	}
+	if ok, _ := bucket.take(); ok {
+		t.Fatal("refill must be capped at burst")
+	}
+}
+
+func TestRecordViolation(t *testing.T) {
+	client := &Client{}
+	for i := 1; i <= 3; i++ {
+		if got := client.recordViolation(time.Minute); got != i {
+			t.Fatalf("violation %d counted as %d", i, got)
+		}
+	}
+
+	// window가 지나면 다시 1부터
+	client.violationsSince = time.Now().Add(-2 * time.Minute)
+	if got := client.recordViolation(time.Minute); got != 1 {
+		t.Fatalf("violations after window = %d, want 1", got)
+	}
+}
+
+func TestTakeTokenFallsBackToLocalBucket(t *testing.T) {
+	// 연결할 수 없는 Redis
+	database.InitRedis(&config.Config{Redis: config.RedisConfig{Host: "127.0.0.1", Port: "1"}})
+	t.Cleanup(func() { database.CloseRedis() })
+
+	s := &MatchServer{}
+	client := &Client{}
+	limit := RateLimit{Rate: 1, Burst: 2}
+
+	for i := 0; i < 2; i++ {
+		if ok, _ := s.takeToken(client, "ratelimit:test", "test", limit); !ok {
+			t.Fatalf("token %d should be allowed by the local bucket", i+1)
+		}
+	}
+	if ok, _ := s.takeToken(client, "ratelimit:test", "test", limit); ok {
+		t.Fatal("local bucket should enforce the burst while Redis is down")
+	}
+}
+
+func TestRejectRateLimitedDisconnectsAfterMaxViolations(t *testing.T) {
+	s := &MatchServer{maxViolations: 3, violationWindow: time.Minute}
+	client, peer := pipeClient(t)
+
+	frames := make(chan struct{}, 8)
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		buffer := make([]byte, 4096)
+		for {
+			if _, err := peer.Read(buffer); err != nil {
+				if err != io.EOF {
+					t.Errorf("unexpected read error: %v", err)
+				}
+				return
+			}
+			frames <- struct{}{}
+		}
+	}()
+
+	for i := 0; i < 2; i++ {
+		s.rejectRateLimited(client, &SocketMessage{Type: "create_match"}, "create_match", time.Second)
+	}
+	select {
+	case <-closed:
+		t.Fatal("connection closed before maxViolations")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	s.rejectRateLimited(client, &SocketMessage{Type: "create_match"}, "create_match", time.Second)
+	select {
+	case <-closed:
+	case <-time.After(time.Second):
+		t.Fatal("connection should be closed after maxViolations")
+	}
+	if len(frames) != 3 {
+		t.Fatalf("got %d error frames, want 3", len(frames))
+	}
+}
@@ -0,0 +1,117 @@
+package socket
+
+import (
+	"game-server/internal/dto"
+	"game-server/internal/pkg/auth"
+	"game-server/internal/pkg/errors"
+	"log"
+	"time"
+)
+
+const (
+	// TOKEN_EXPIRY_WARNING 토큰 만료 전에 token_expiring 알림을 보내는 시점
+	TOKEN_EXPIRY_WARNING = time.Minute
+)
+
+// setClaims 연결에 적용된 토큰을 교체하고 만료 타이머를 다시 설정
+func (s *MatchServer) setClaims(client *Client, claims *auth.Claims) {
+	client.tokenMux.Lock()
+	defer client.tokenMux.Unlock()
+
+	client.Claims = claims
+	client.tokenGeneration++
+	client.stopTokenTimersLocked()
+
+	if claims.ExpiresAt == nil {
+		return
+	}
+	expiresAt := claims.ExpiresAt.Time
+	generation := client.tokenGeneration
+
+	client.warningTimer = time.AfterFunc(max(0, time.Until(expiresAt)-TOKEN_EXPIRY_WARNING), func() {
+		defer s.recoverPanic(client, nil)
+		if !client.isTokenGeneration(generation) {
+			return
+		}
+		s.sendToClient(client, SocketMessage{
+			Type: "token_expiring",
+			Data: dto.TokenExpiringResponse{
+				ExpiresAt: expiresAt.Unix(),
+				Message:   "Access token is about to expire, send reauth with a fresh token",
+			},
+		})
+	})
+
+	client.expiryTimer = time.AfterFunc(time.Until(expiresAt), func() {
+		defer s.recoverPanic(client, nil)
+		if !client.isTokenGeneration(generation) {
+			return
+		}
+		log.Printf("Closing client %s (user: %s): access token expired", client.ID, client.UserID)
+		s.sendErrorToClient(client, nil, errors.TokenExpired())
+		client.Conn.Close()
+	})
+}
+
+// currentClaims 현재 연결에 적용된 토큰 (reauth로 교체될 수 있음)
+func (c *Client) currentClaims() *auth.Claims {
+	c.tokenMux.Lock()
+	defer c.tokenMux.Unlock()
+	return c.Claims
+}
+
+func (c *Client) isTokenGeneration(generation int) bool {
+	c.tokenMux.Lock()
+	defer c.tokenMux.Unlock()
+	return c.tokenGeneration == generation
+}
+
+func (c *Client) stopTokenTimers() {
+	c.tokenMux.Lock()
+	defer c.tokenMux.Unlock()
+	c.tokenGeneration++
+	c.stopTokenTimersLocked()
+}
+
+func (c *Client) stopTokenTimersLocked() {
+	if c.warningTimer != nil {
+		c.warningTimer.Stop()
+		c.warningTimer = nil
+	}
+	if c.expiryTimer != nil {
+		c.expiryTimer.Stop()
+		c.expiryTimer = nil
+	}
+}
+
+func (s *MatchServer) handleReauth(client *Client, msg *SocketMessage, req *dto.ReauthRequest) (*dto.ReauthResponse, error) {
+	if req.Token == "" {
+		return nil, errors.TokenRequired()
+	}
+
+	claims, err := auth.ValidateAccessToken(req.Token)
+	if err != nil {
+		return nil, authError(err)
+	}
+
+	// 같은 사용자의 토큰으로만 갱신 가능
+	if claims.UserID != client.UserID {
+		return nil, errors.TokenUserMismatch()
+	}
+
+	s.setClaims(client, claims)
+
+	log.Printf("User %s refreshed access token on client %s", client.UserID, client.ID)
+	return &dto.ReauthResponse{
+		UserID:    claims.UserID,
+		ExpiresAt: claims.ExpiresAt.Unix(),
+	}, nil
+}
+
+// authError 폐기/차단 사유는 그대로, 나머지 검증 실패는 UNAUTHORIZED로 전달
+func authError(err error) *errors.AppError {
+	if appErr, ok := err.(*errors.AppError); ok {
+		return appErr
+	}
+	return errors.Unauthorized()
+}
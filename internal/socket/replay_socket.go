@@ -0,0 +1,214 @@
+package socket
+
+import (
+	"game-server/internal/dto"
+	"game-server/internal/pkg/auth"
+	"game-server/internal/pkg/errors"
+	"game-server/internal/service"
+	"log"
+	"sync"
+	"time"
+)
+
+// replayPlayer 클라이언트 하나에 저장된 게임 이벤트를 시간 순서대로 재생
+type replayPlayer struct {
+	replay   *service.Replay
+	commands chan dto.ReplayControlRequest
+	stop     chan struct{}
+	stopOnce sync.Once
+}
+
+func (p *replayPlayer) close() {
+	p.stopOnce.Do(func() {
+		close(p.stop)
+	})
+}
+
+func (c *Client) setReplay(player *replayPlayer) {
+	c.replayMux.Lock()
+	defer c.replayMux.Unlock()
+	if c.replay != nil {
+		c.replay.close()
+	}
+	c.replay = player
+}
+
+func (c *Client) currentReplay() *replayPlayer {
+	c.replayMux.Lock()
+	defer c.replayMux.Unlock()
+	return c.replay
+}
+
+// clearReplay 재생이 끝난 플레이어가 아직 현재 리플레이일 때만 해제
+func (c *Client) clearReplay(player *replayPlayer) {
+	c.replayMux.Lock()
+	defer c.replayMux.Unlock()
+	if c.replay == player {
+		c.replay = nil
+	}
+}
+
+func (c *Client) stopReplay() {
+	c.setReplay(nil)
+}
+
+// canWatchReplay 게임 참가자는 자기 게임만, 관리자/분석가는 모든 게임의 리플레이를 관전
+func canWatchReplay(client *Client, replay *service.Replay) bool {
+	if claims := client.currentClaims(); claims != nil && claims.HasAnyRole(auth.ROLE_ADMIN, auth.ROLE_ANALYST) {
+		return true
+	}
+	return replay.HasParticipant(client.UserID)
+}
+
+func (s *MatchServer) handleReplayStart(client *Client, msg *SocketMessage, req *dto.ReplayStartRequest) {
+	if req.Speed == 0 {
+		req.Speed = 1
+	}
+	if !service.ValidReplaySpeed(req.Speed) {
+		s.sendErrorToClient(client, msg, errors.UnsupportedReplaySpeed())
+		return
+	}
+
+	// 서비스로 위임
+	replay, err := s.replayService.LoadReplay(req.GameID)
+	if err != nil {
+		s.sendErrorToClient(client, msg, err)
+		return
+	}
+	if !canWatchReplay(client, replay) {
+		s.sendErrorToClient(client, msg, errors.Forbidden())
+		return
+	}
+
+	player := &replayPlayer{
+		replay:   replay,
+		commands: make(chan dto.ReplayControlRequest, 8),
+		stop:     make(chan struct{}),
+	}
+	client.setReplay(player)
+
+	s.reply(client, msg, SocketMessage{
+		Type: "replay_started",
+		Data: dto.ReplayStartedResponse{
+			GameID:     replay.GameID,
+			DurationMs: replay.Duration(),
+			EventCount: len(replay.Events),
+			Speed:      req.Speed,
+		},
+	})
+
+	go s.runReplay(client, player, req.Speed)
+
+	log.Printf("User %s started replay of game %s at %.1fx", client.UserID, replay.GameID, req.Speed)
+}
+
+func (s *MatchServer) handleReplayControl(client *Client, msg *SocketMessage, req *dto.ReplayControlRequest) {
+	switch req.Action {
+	case "pause", "resume", "seek":
+	case "speed":
+		if !service.ValidReplaySpeed(req.Speed) {
+			s.sendErrorToClient(client, msg, errors.UnsupportedReplaySpeed())
+			return
+		}
+	default:
+		s.sendErrorToClient(client, msg, errors.BadRequestWithMessage("알 수 없는 리플레이 제어 요청입니다"))
+		return
+	}
+
+	player := client.currentReplay()
+	if player == nil {
+		s.sendErrorToClient(client, msg, errors.ReplayNotActive())
+		return
+	}
+
+	select {
+	case player.commands <- *req:
+		s.ack(client, msg)
+	case <-player.stop:
+		s.sendErrorToClient(client, msg, errors.ReplayNotActive())
+	default:
+		s.sendErrorToClient(client, msg, errors.ReplayBusy())
+	}
+}
+
+func (s *MatchServer) handleReplayStop(client *Client, msg *SocketMessage) (map[string]string, error) {
+	player := client.currentReplay()
+	if player == nil {
+		return nil, errors.ReplayNotActive()
+	}
+	client.clearReplay(player)
+	player.close()
+
+	return map[string]string{"gameId": player.replay.GameID}, nil
+}
+
+// runReplay 이벤트 간 간격을 배속에 맞춰 기다리며 순서대로 전송
+func (s *MatchServer) runReplay(client *Client, player *replayPlayer, speed float64) {
+	defer s.recoverPanic(client, nil)
+
+	replay := player.replay
+	var position int64
+	index := 0
+	paused := false
+
+	for {
+		if index >= len(replay.Events) && !paused {
+			s.sendToClient(client, SocketMessage{
+				Type: "replay_ended",
+				Data: map[string]string{"gameId": replay.GameID},
+			})
+			client.clearReplay(player)
+			player.close()
+			return
+		}
+
+		var timer *time.Timer
+		var fire <-chan time.Time
+		waitStarted := time.Now()
+		if !paused {
+			wait := time.Duration(float64(replay.Offset(index)-position)/speed) * time.Millisecond
+			timer = time.NewTimer(wait)
+			fire = timer.C
+		}
+
+		select {
+		case <-player.stop:
+			if timer != nil {
+				timer.Stop()
+			}
+			return
+
+		case <-fire:
+			s.sendToClient(client, SocketMessage{
+				Type: "replay_event",
+				Data: replay.ToReplayEvent(index),
+			})
+			position = replay.Offset(index)
+			index++
+
+		case cmd := <-player.commands:
+			if timer != nil {
+				timer.Stop()
+				// 기다리는 동안 진행된 재생 위치 반영
+				elapsed := int64(float64(time.Since(waitStarted).Milliseconds()) * speed)
+				position = min(position+elapsed, replay.Offset(index))
+			}
+
+			switch cmd.Action {
+			case "pause":
+				paused = true
+			case "resume":
+				paused = false
+			case "speed":
+				speed = cmd.Speed
+			case "seek":
+				position = max(0, min(cmd.PositionMs, replay.Duration()))
+				index = replay.IndexAt(position)
+				s.sendToClient(client, SocketMessage{
+					Type: "replay_state",
+					Data: replay.StateAt(position),
+				})
+			}
+		}
+	}
+}
@@ -0,0 +1,129 @@
+package socket
+
+import (
+	"fmt"
+	"game-server/internal/pkg/database"
+	"log"
+	"strings"
+	"time"
+)
+
+const (
+	// SocketMessage.Kind 값
+	MESSAGE_KIND_EVENT = "event" // 서버가 먼저 보내는 알림/브로드캐스트
+	MESSAGE_KIND_REPLY = "reply" // 클라이언트 요청에 대한 직접 응답
+
+	// 같은 요청 ID로 재전송된 요청을 다시 처리하지 않고 기억해둔 응답을 돌려주는 기간
+	REQUEST_DEDUPE_TTL = 5 * time.Minute
+
+	requestPending = "pending"
+)
+
+// beginRequest 요청 ID가 있는 요청의 중복 여부 확인
+// 처음 보는 요청이면 true, 이미 처리된 요청이면 저장된 응답을 다시 보내고 false
+func (s *MatchServer) beginRequest(client *Client, msg *SocketMessage) bool {
+	if msg.ID == "" {
+		return true
+	}
+
+	key := requestKey(client.UserID, msg.ID)
+	created, err := database.SetNX(key, requestPending, REQUEST_DEDUPE_TTL)
+	if err != nil {
+		// Redis 장애 시에는 중복 검사 없이 처리
+		log.Printf("Failed to check duplicate request %s from user %s: %v", msg.ID, client.UserID, err)
+		return true
+	}
+	if created {
+		return true
+	}
+
+	cached, err := database.Get(key)
+	if err != nil || cached == requestPending {
+		log.Printf("Ignoring duplicate request %s (%s) from user %s while in progress", msg.ID, msg.Type, client.UserID)
+		return false
+	}
+
+	log.Printf("Replaying cached reply for duplicate request %s (%s) from user %s", msg.ID, msg.Type, client.UserID)
+	codec := client.currentCodec()
+	msgBytes, err := cachedReply(codec, cached)
+	if err != nil {
+		log.Printf("Failed to replay cached reply for request %s: %v", msg.ID, err)
+		return false
+	}
+	s.writeToClient(client, codec, msgBytes)
+	return false
+}
+
+// cachedReply 저장된 응답을 현재 연결의 인코딩으로 변환
+// 재접속하면서 인코딩이 바뀐 경우에만 디코딩 후 다시 인코딩
+func cachedReply(target Codec, cached string) ([]byte, error) {
+	name, encoded, _ := strings.Cut(cached, ":")
+	codec := codecByName(name)
+	if codec == nil {
+		return nil, fmt.Errorf("unknown codec: %s", name)
+	}
+	if codec == target {
+		return []byte(encoded), nil
+	}
+
+	var reply interface{}
+	if err := codec.Unmarshal([]byte(encoded), &reply); err != nil {
+		return nil, err
+	}
+	return target.Marshal(reply)
+}
+
+// reply 요청에 대한 직접 응답 전송 (요청 ID를 돌려주고 중복 요청에 대비해 응답을 저장)
+func (s *MatchServer) reply(client *Client, req *SocketMessage, msg SocketMessage) {
+	req.answered = true
+	msg.ID = req.ID
+	msg.Kind = MESSAGE_KIND_REPLY
+
+	codec := client.currentCodec()
+	msgBytes, err := codec.Marshal(msg)
+	if err != nil {
+		log.Printf("Error marshaling message: %v", err)
+		return
+	}
+	s.writeToClient(client, codec, msgBytes)
+
+	// 응답은 인코딩 이름과 함께 저장
+	if req.ID != "" && client.UserID != "" {
+		cached := codec.Name() + ":" + string(msgBytes)
+		if err := database.Set(requestKey(client.UserID, req.ID), cached, REQUEST_DEDUPE_TTL); err != nil {
+			log.Printf("Failed to cache reply for request %s: %v", req.ID, err)
+		}
+	}
+}
+
+// ack 별도 응답이 없는 요청의 처리 완료 알림 (요청 ID가 있을 때만)
+func (s *MatchServer) ack(client *Client, req *SocketMessage) {
+	if req.ID == "" {
+		return
+	}
+	s.reply(client, req, SocketMessage{
+		Type: "ack",
+		Data: map[string]string{"type": req.Type},
+	})
+}
+
+// releaseRequest 실패한 요청은 같은 ID로 다시 시도할 수 있게 기록 삭제
+func (s *MatchServer) releaseRequest(client *Client, req *SocketMessage) {
+	if req.ID == "" || client.UserID == "" {
+		return
+	}
+	if err := database.Del(requestKey(client.UserID, req.ID)); err != nil {
+		log.Printf("Failed to release request %s: %v", req.ID, err)
+	}
+}
+
+// finishRequest 핸들러가 응답 없이 끝났으면 처리 중 표시를 지워서 같은 ID로 재시도할 수 있게 함
+func (s *MatchServer) finishRequest(client *Client, req *SocketMessage) {
+	if !req.answered {
+		s.releaseRequest(client, req)
+	}
+}
+
+func requestKey(userID, requestID string) string {
+	return fmt.Sprintf("socket:requests:%s:%s", userID, requestID)
+}
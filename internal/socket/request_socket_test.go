@@ -0,0 +1,84 @@
+package socket
+
+import (
+	"bytes"
+	"game-server/internal/pkg/database/redistest"
+	"net"
+	"sync"
+	"testing"
+	"time"
+)
+
+// frameConn 보낸 프레임을 그대로 기록하는 연결
+type frameConn struct {
+	net.Conn
+	mu     sync.Mutex
+	frames [][]byte
+}
+
+func (c *frameConn) Write(p []byte) (int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.frames = append(c.frames, append([]byte(nil), p...))
+	return len(p), nil
+}
+
+func (c *frameConn) Close() error { return nil }
+
+func (c *frameConn) sent() [][]byte {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([][]byte(nil), c.frames...)
+}
+
+func TestDuplicateRequestIsAnsweredFromCache(t *testing.T) {
+	redis := redistest.Start(t)
+
+	calls := 0
+	s := &MatchServer{registry: NewMessageRegistry()}
+	s.registry.Register(RouteNoData("ping", func(client *Client, msg *SocketMessage) {
+		calls++
+		s.reply(client, msg, SocketMessage{Type: "pong", Data: map[string]int{"calls": calls}})
+	}))
+
+	conn := &frameConn{}
+	client := &Client{ID: "client-1", UserID: "user-1", Conn: conn, codec: JSONCodec, readCodec: JSONCodec, authenticated: true}
+	send := func(id string) {
+		s.dispatch(client, &SocketMessage{ID: id, Type: "ping"})
+	}
+
+	// 처음 받은 요청은 처리하고 응답을 저장
+	send("req-1")
+	if calls != 1 || len(conn.sent()) != 1 {
+		t.Fatalf("first request: calls=%d frames=%d", calls, len(conn.sent()))
+	}
+	if !redis.Exists(requestKey("user-1", "req-1")) {
+		t.Fatal("reply should be cached under the request ID")
+	}
+
+	// 같은 ID로 재전송하면 핸들러를 다시 실행하지 않고 저장된 응답을 보냄
+	send("req-1")
+	frames := conn.sent()
+	if calls != 1 {
+		t.Fatalf("handler ran again for a duplicate request (calls=%d)", calls)
+	}
+	if len(frames) != 2 || !bytes.Equal(frames[0], frames[1]) {
+		t.Fatalf("duplicate should get the cached reply, frames=%q", frames)
+	}
+
+	// 다른 ID는 새 요청
+	send("req-2")
+	if calls != 2 {
+		t.Fatalf("new request ID should be processed (calls=%d)", calls)
+	}
+
+	// TTL이 지나면 같은 ID도 다시 처리
+	redis.FastForward(REQUEST_DEDUPE_TTL + time.Second)
+	if redis.Exists(requestKey("user-1", "req-1")) {
+		t.Fatal("cached reply should expire after the dedupe TTL")
+	}
+	send("req-1")
+	if calls != 3 {
+		t.Fatalf("request ID should be processed again after the TTL (calls=%d)", calls)
+	}
+}
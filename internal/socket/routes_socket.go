@@ -0,0 +1,137 @@
+package socket
+
+import (
+	"game-server/internal/service"
+	"log"
+)
+
+// registerRoutes 소켓 메시지 타입별 핸들러 등록
+// 새 메시지를 추가할 때는 요청 DTO와 핸들러만 만들고 여기에 등록
+func (s *MatchServer) registerRoutes() {
+	r := s.registry
+	r.Use(
+		s.recoveryMiddleware,
+		loggingMiddleware,
+		metricsMiddleware,
+		s.rateLimitMiddleware,
+		s.authorizeMiddleware,
+	)
+
+	// 응답이 하나뿐인 메시지는 RouteWithReply로 응답 타입을 함께 등록
+	// 응답 종류가 여럿이거나 응답 뒤에 이어서 보내는 메시지가 있으면 핸들러가 직접 전송
+
+	// 인증 (auth_success는 중복 요청 캐시 없이 핸들러가 직접 전송)
+	authenticate := Route("auth", s.authenticateClient)
+	authenticate.Public = true
+	r.Register(authenticate)
+
+	reauth := RouteWithReply("reauth", "reauth_success", s.handleReauth)
+	reauth.RateLimit = RateLimit{Rate: 0.2, Burst: 3}
+	r.Register(reauth)
+
+	// 매치 (플레이할 수 없는 기기는 초대와 매치 나가기만 가능)
+	createMatch := RouteWithReply("create_match", "match_created", s.handleCreateMatch)
+	createMatch.RateLimit = RateLimit{Rate: 1, Burst: 3}
+	createMatch.Capability = service.SESSION_CAPABILITY_PLAY
+	r.Register(createMatch)
+
+	inviteFriends := RouteWithReply("invite_friends", "friends_invited", s.handleInviteFriends)
+	inviteFriends.RateLimit = RateLimit{Rate: 1, Burst: 5}
+	r.Register(inviteFriends)
+
+	respondInvite := Route("respond_invite", s.handleRespondInvite) // invite_accepted 또는 invite_declined
+	respondInvite.RateLimit = RateLimit{Rate: 2, Burst: 5}
+	respondInvite.Capability = service.SESSION_CAPABILITY_PLAY
+	r.Register(respondInvite)
+
+	listLobbies := RouteWithReply("list_lobbies", "lobbies_list", s.handleListLobbies)
+	listLobbies.RateLimit = RateLimit{Rate: 1, Burst: 5}
+	r.Register(listLobbies)
+
+	joinLobby := RouteWithReply("join_lobby", "lobby_joined", s.handleJoinLobby)
+	joinLobby.RateLimit = RateLimit{Rate: 1, Burst: 3}
+	joinLobby.Capability = service.SESSION_CAPABILITY_PLAY
+	r.Register(joinLobby)
+
+	startMatch := Route("start_match", s.handleStartMatch) // match_started 응답 후 게임 시작
+	startMatch.RateLimit = RateLimit{Rate: 1, Burst: 2}
+	startMatch.Capability = service.SESSION_CAPABILITY_PLAY
+	r.Register(startMatch)
+
+	leaveMatch := RouteNoDataWithReply("leave_match", "match_left", s.handleLeaveMatch)
+	leaveMatch.RateLimit = RateLimit{Rate: 1, Burst: 3}
+	r.Register(leaveMatch)
+
+	// 게임
+	gameEvent := Route("game_event", s.handleGameEvent)
+	gameEvent.Quiet = true
+	gameEvent.RateLimit = RateLimit{Rate: 30, Burst: 60}
+	gameEvent.Capability = service.SESSION_CAPABILITY_PLAY
+	r.Register(gameEvent)
+
+	// 접속 상태
+	subscribePresence := RouteWithReply("subscribe_presence", "presence_subscribed", s.handleSubscribePresence)
+	subscribePresence.RateLimit = RateLimit{Rate: 1, Burst: 5}
+	r.Register(subscribePresence)
+
+	unsubscribePresence := Route("unsubscribe_presence", s.handleUnsubscribePresence)
+	unsubscribePresence.RateLimit = RateLimit{Rate: 1, Burst: 5}
+	r.Register(unsubscribePresence)
+
+	setPresence := RouteWithReply("set_presence", "presence_updated", s.handleSetPresence)
+	setPresence.RateLimit = RateLimit{Rate: 0.5, Burst: 3}
+	r.Register(setPresence)
+
+	// 친구
+	listFriends := RouteNoDataWithReply("list_friends", "friends_list", s.handleListFriends)
+	listFriends.RateLimit = RateLimit{Rate: 1, Burst: 5}
+	r.Register(listFriends)
+
+	sendFriendRequest := RouteWithReply("send_friend_request", "friend_request_sent", s.handleSendFriendRequest)
+	sendFriendRequest.RateLimit = RateLimit{Rate: 0.5, Burst: 5}
+	r.Register(sendFriendRequest)
+
+	acceptFriendRequest := RouteWithReply("accept_friend_request", "friend_added", s.handleAcceptFriendRequest)
+	acceptFriendRequest.RateLimit = RateLimit{Rate: 1, Burst: 5}
+	r.Register(acceptFriendRequest)
+
+	declineFriendRequest := Route("decline_friend_request", s.handleDeclineFriendRequest)
+	declineFriendRequest.RateLimit = RateLimit{Rate: 1, Burst: 5}
+	r.Register(declineFriendRequest)
+
+	removeFriend := Route("remove_friend", s.handleRemoveFriend)
+	removeFriend.RateLimit = RateLimit{Rate: 1, Burst: 5}
+	r.Register(removeFriend)
+
+	blockUser := Route("block_user", s.handleBlockUser)
+	blockUser.RateLimit = RateLimit{Rate: 1, Burst: 5}
+	r.Register(blockUser)
+
+	unblockUser := Route("unblock_user", s.handleUnblockUser)
+	unblockUser.RateLimit = RateLimit{Rate: 1, Burst: 5}
+	r.Register(unblockUser)
+
+	// 리플레이
+	replayStart := Route("replay_start", s.handleReplayStart) // replay_started 응답 후 재생 시작
+	replayStart.RateLimit = RateLimit{Rate: 0.5, Burst: 2}
+	r.Register(replayStart)
+
+	replayControl := Route("replay_control", s.handleReplayControl)
+	replayControl.Quiet = true
+	replayControl.RateLimit = RateLimit{Rate: 5, Burst: 10}
+	r.Register(replayControl)
+
+	replayStop := RouteNoDataWithReply("replay_stop", "replay_stopped", s.handleReplayStop)
+	replayStop.RateLimit = RateLimit{Rate: 1, Burst: 3}
+	r.Register(replayStop)
+
+	// 설정으로 메시지 타입별 제한 덮어쓰기
+	for msgType, rule := range s.rateLimits {
+		route, ok := r.lookup(msgType)
+		if !ok {
+			log.Printf("Ignoring rate limit for unknown socket message type %s", msgType)
+			continue
+		}
+		route.RateLimit = RateLimit{Rate: rule.Rate, Burst: rule.Burst}
+	}
+}
@@ -0,0 +1,96 @@
+package socket
+
+import (
+	"encoding/json"
+	"game-server/internal/dto"
+	"game-server/internal/pkg/database"
+	"game-server/internal/service"
+	"log"
+	"slices"
+	"time"
+)
+
+// heartbeatSessions 이 노드의 세션이 살아 있음을 주기적으로 표시 (종료하면 멈추고 NODE_TTL 후 만료)
+func (s *MatchServer) heartbeatSessions() {
+	ticker := time.NewTicker(service.NODE_HEARTBEAT_INTERVAL)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.stop:
+			return
+		case <-ticker.C:
+		}
+		if err := s.sessionService.Heartbeat(); err != nil {
+			log.Printf("Failed to refresh node heartbeat: %v", err)
+		}
+	}
+}
+
+// watchSessions 다른 노드(또는 이 노드)에서 같은 사용자가 새로 접속하면 기존 세션을 종료
+func (s *MatchServer) watchSessions() {
+	pubsub := database.Subscribe(service.SESSION_CHANNEL)
+	defer pubsub.Close()
+
+	for message := range pubsub.Channel() {
+		var replaced dto.SessionReplaced
+		if err := json.Unmarshal([]byte(message.Payload), &replaced); err != nil {
+			log.Printf("Invalid session message: %v", err)
+			continue
+		}
+		s.kickReplacedSessions(&replaced)
+	}
+}
+
+func (s *MatchServer) kickReplacedSessions(replaced *dto.SessionReplaced) {
+	s.clientsMux.RLock()
+	var targets []*Client
+	for _, client := range s.clients {
+		if client.UserID == replaced.UserID && client.Device == replaced.Device && client.ID != replaced.SocketID {
+			targets = append(targets, client)
+		}
+	}
+	s.clientsMux.RUnlock()
+
+	for _, client := range targets {
+		// 매치/게임은 새 세션이 이어받으므로 연결만 정리
+		client.handedOff.Store(true)
+		s.sendToClient(client, SocketMessage{
+			Type: "session_replaced",
+			Data: dto.SessionReplacedNotice{
+				Message: "Signed in from another location",
+			},
+		})
+		client.Conn.Close()
+		log.Printf("Closed client %s (user: %s): replaced by %s", client.ID, client.UserID, replaced.SocketID)
+	}
+}
+
+// getClientsByUserID 이 노드에 연결된 사용자의 세션 (capability를 주면 그 기능이 있는 세션만)
+func (s *MatchServer) getClientsByUserID(userID string, capability string) []*Client {
+	sessions, err := s.sessionService.Sessions(userID)
+	if err != nil || len(sessions) == 0 {
+		return nil
+	}
+
+	s.clientsMux.RLock()
+	defer s.clientsMux.RUnlock()
+
+	var clients []*Client
+	for _, session := range sessions {
+		if capability != "" && !slices.Contains(session.Capabilities, capability) {
+			continue
+		}
+		if client, ok := s.clients[session.SocketID]; ok {
+			clients = append(clients, client)
+		}
+	}
+	return clients
+}
+
+// sendToUser 사용자의 모든 세션으로 전송 (capability를 주면 그 기능이 있는 세션에만)
+func (s *MatchServer) sendToUser(userID string, msg SocketMessage, capability string) {
+	for _, client := range s.getClientsByUserID(userID, capability) {
+		s.sendToClient(client, msg)
+	}
+}
@@ -0,0 +1,103 @@
+package socket
+
+import (
+	"context"
+	"game-server/internal/dto"
+	"log"
+	"math/rand/v2"
+	"sync"
+	"time"
+)
+
+// Shutdown 새 연결과 요청을 막고 접속 중인 클라이언트에게 종료를 알린 뒤
+// 진행 중인 핸들러가 끝나기를 기다렸다가 연결을 정리
+// 매치/게임 상태는 남겨둬서 클라이언트가 다른 노드로 재접속해 이어갈 수 있게 함
+func (s *MatchServer) Shutdown(ctx context.Context, reconnectDelay time.Duration) error {
+	s.drainMux.Lock()
+	if !s.closing.Load() {
+		close(s.stop)
+	}
+	s.closing.Store(true)
+	s.drainMux.Unlock()
+
+	if s.listener != nil {
+		s.listener.Close()
+	}
+
+	clients := s.snapshotClients()
+	log.Printf("Match server shutting down, notifying %d clients", len(clients))
+
+	// 모든 클라이언트가 동시에 재접속하지 않도록 대기 시간을 분산
+	for _, client := range clients {
+		jitter := time.Duration(rand.Int64N(int64(reconnectDelay) + 1))
+		s.sendToClient(client, SocketMessage{
+			Type: "server_shutdown",
+			Data: dto.ServerShutdownNotice{
+				Message:          "Server is shutting down, please reconnect",
+				ReconnectAfterMs: (reconnectDelay + jitter).Milliseconds(),
+			},
+		})
+	}
+
+	if err := waitWithContext(ctx, &s.handlerWG); err != nil {
+		log.Printf("Timed out waiting for in-flight socket handlers: %v", err)
+	}
+
+	// 연결을 닫으면 각 읽기 루프가 끝나면서 removeClient로 소켓 매핑이 정리됨
+	s.connsMux.Lock()
+	for client := range s.conns {
+		client.handedOff.Store(true)
+		client.Conn.Close()
+	}
+	s.connsMux.Unlock()
+
+	if err := waitWithContext(ctx, &s.connWG); err != nil {
+		log.Printf("Timed out waiting for socket connections to close: %v", err)
+		return err
+	}
+
+	log.Println("Match server stopped")
+	return nil
+}
+
+// beginHandler 종료 중이 아니면 진행 중인 핸들러로 등록 (끝나면 endHandler 호출)
+func (s *MatchServer) beginHandler() bool {
+	s.drainMux.RLock()
+	defer s.drainMux.RUnlock()
+
+	if s.closing.Load() {
+		return false
+	}
+	s.handlerWG.Add(1)
+	return true
+}
+
+func (s *MatchServer) endHandler() {
+	s.handlerWG.Done()
+}
+
+func (s *MatchServer) snapshotClients() []*Client {
+	s.clientsMux.RLock()
+	defer s.clientsMux.RUnlock()
+
+	clients := make([]*Client, 0, len(s.clients))
+	for _, client := range s.clients {
+		clients = append(clients, client)
+	}
+	return clients
+}
+
+func waitWithContext(ctx context.Context, wg *sync.WaitGroup) error {
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
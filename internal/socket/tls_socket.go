@@ -0,0 +1,181 @@
+package socket
+
+import (
+	"bufio"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"game-server/internal/config"
+	"log"
+	"net"
+	"os"
+	"sync"
+	"time"
+)
+
+// certReloader 인증서 파일이 바뀌면 다시 읽어서 새 핸드셰이크부터 적용
+// 이미 맺어진 연결은 기존 인증서로 계속 유지됨
+type certReloader struct {
+	certFile string
+	keyFile  string
+
+	mu      sync.RWMutex
+	cert    *tls.Certificate
+	modTime time.Time
+}
+
+func newCertReloader(certFile, keyFile string) (*certReloader, error) {
+	reloader := &certReloader{
+		certFile: certFile,
+		keyFile:  keyFile,
+	}
+	if _, err := reloader.reload(); err != nil {
+		return nil, err
+	}
+	return reloader, nil
+}
+
+// reload 파일 수정 시각이 바뀌었으면 인증서를 다시 읽음 (바뀌었으면 true)
+func (r *certReloader) reload() (bool, error) {
+	modTime, err := latestModTime(r.certFile, r.keyFile)
+	if err != nil {
+		return false, err
+	}
+
+	r.mu.RLock()
+	unchanged := r.cert != nil && modTime.Equal(r.modTime)
+	r.mu.RUnlock()
+	if unchanged {
+		return false, nil
+	}
+
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return false, fmt.Errorf("failed to load TLS certificate: %w", err)
+	}
+
+	r.mu.Lock()
+	r.cert = &cert
+	r.modTime = modTime
+	r.mu.Unlock()
+	return true, nil
+}
+
+func (r *certReloader) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.cert, nil
+}
+
+// watch 주기적으로 인증서 파일 변경 확인 (실패하면 기존 인증서 유지), stop이 닫히면 종료
+func (r *certReloader) watch(interval time.Duration, stop <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+
+		changed, err := r.reload()
+		if err != nil {
+			log.Printf("Failed to reload TLS certificate, keeping current one: %v", err)
+			continue
+		}
+		if changed {
+			log.Printf("TLS certificate reloaded from %s", r.certFile)
+		}
+	}
+}
+
+func latestModTime(files ...string) (time.Time, error) {
+	var latest time.Time
+	for _, file := range files {
+		info, err := os.Stat(file)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("failed to stat %s: %w", file, err)
+		}
+		if info.ModTime().After(latest) {
+			latest = info.ModTime()
+		}
+	}
+	return latest, nil
+}
+
+// newTLSConfig 매치 소켓용 TLS 설정 생성 (TLS를 쓰지 않으면 nil)
+// 인증서 재로드는 stop이 닫힐 때까지 계속됨
+func newTLSConfig(cfg config.TLSConfig, stop <-chan struct{}) (*tls.Config, error) {
+	if !cfg.Enabled() {
+		return nil, nil
+	}
+
+	minVersion, err := parseTLSVersion(cfg.MinVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	reloader, err := newCertReloader(cfg.CertFile, cfg.KeyFile)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.ReloadInterval > 0 {
+		go reloader.watch(cfg.ReloadInterval, stop)
+	}
+
+	tlsConfig := &tls.Config{
+		MinVersion:     minVersion,
+		GetCertificate: reloader.GetCertificate,
+	}
+
+	// 내부 게임 서버는 클라이언트 인증서를 제시하고, 일반 게임 클라이언트는 인증서 없이 접속
+	if cfg.ClientCAFile != "" {
+		caPEM, err := os.ReadFile(cfg.ClientCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read client CA file: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caPEM) {
+			return nil, fmt.Errorf("no certificates found in client CA file %s", cfg.ClientCAFile)
+		}
+		tlsConfig.ClientCAs = pool
+		tlsConfig.ClientAuth = tls.VerifyClientCertIfGiven
+	}
+
+	return tlsConfig, nil
+}
+
+func parseTLSVersion(version string) (uint16, error) {
+	switch version {
+	case "1.2":
+		return tls.VersionTLS12, nil
+	case "1.3":
+		return tls.VersionTLS13, nil
+	default:
+		return 0, fmt.Errorf("unsupported TLS min version: %s (use 1.2 or 1.3)", version)
+	}
+}
+
+// bufferedConn PROXY 헤더를 읽느라 버퍼에 남은 바이트부터 TLS 핸드셰이크에 넘기기 위한 연결
+type bufferedConn struct {
+	net.Conn
+	reader *bufio.Reader
+}
+
+func (c *bufferedConn) Read(p []byte) (int, error) {
+	return c.reader.Read(p)
+}
+
+// startTLS 연결을 TLS로 감싸고 핸드셰이크 완료 (검증된 클라이언트 인증서가 있으면 서비스 이름 기록)
+func (s *MatchServer) startTLS(client *Client, conn net.Conn, reader *bufio.Reader) (*tls.Conn, error) {
+	tlsConn := tls.Server(&bufferedConn{Conn: conn, reader: reader}, s.tlsConfig)
+	if err := tlsConn.Handshake(); err != nil {
+		return nil, err
+	}
+
+	if state := tlsConn.ConnectionState(); len(state.PeerCertificates) > 0 {
+		client.ServiceName = state.PeerCertificates[0].Subject.CommonName
+	}
+	return tlsConn, nil
+}
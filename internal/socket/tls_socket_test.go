@@ -0,0 +1,370 @@
+package socket
+
+import (
+	"bufio"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"game-server/internal/config"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// testCA 테스트용 인증서를 서명하는 CA
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	pem  []byte
+}
+
+func newTestCA(t *testing.T) *testCA {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test-ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &testCA{cert: cert, key: key, pem: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})}
+}
+
+func (ca *testCA) pool() *x509.CertPool {
+	pool := x509.NewCertPool()
+	pool.AddCert(ca.cert)
+	return pool
+}
+
+// issue CA로 서명한 인증서와 키를 PEM으로 반환
+func (ca *testCA) issue(t *testing.T, commonName string, serial int64, usage x509.ExtKeyUsage) ([]byte, []byte) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+		DNSNames:     []string{"localhost"},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.cert, &key.PublicKey, ca.key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+}
+
+// writeServerCert 서버 인증서를 파일로 쓰고 수정 시각을 modTime으로 맞춤
+func writeServerCert(t *testing.T, ca *testCA, dir string, serial int64, modTime time.Time) (string, string) {
+	t.Helper()
+	certPEM, keyPEM := ca.issue(t, "localhost", serial, x509.ExtKeyUsageServerAuth)
+	certFile := filepath.Join(dir, "server.crt")
+	keyFile := filepath.Join(dir, "server.key")
+	for file, data := range map[string][]byte{certFile: certPEM, keyFile: keyPEM} {
+		if err := os.WriteFile(file, data, 0o600); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chtimes(file, modTime, modTime); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return certFile, keyFile
+}
+
+// tlsHandshakeResult 서버 쪽 startTLS 결과
+type tlsHandshakeResult struct {
+	client *Client
+	conn   *tls.Conn
+	err    error
+}
+
+// startTLSListener startTLS로 연결을 받는 서버 (핸드셰이크 결과를 results로 전달)
+func startTLSListener(t *testing.T, s *MatchServer) (string, <-chan tlsHandshakeResult) {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	results := make(chan tlsHandshakeResult, 4)
+	go func() {
+		var conns []net.Conn
+		defer func() {
+			for _, conn := range conns {
+				conn.Close()
+			}
+		}()
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			conns = append(conns, conn)
+			go func() {
+				client := &Client{Conn: conn}
+				conn.SetDeadline(time.Now().Add(2 * time.Second))
+				tlsConn, err := s.startTLS(client, conn, bufio.NewReaderSize(conn, 4096))
+				results <- tlsHandshakeResult{client: client, conn: tlsConn, err: err}
+			}()
+		}
+	}()
+	return listener.Addr().String(), results
+}
+
+func dialTLS(t *testing.T, addr string, config *tls.Config) *tls.Conn {
+	t.Helper()
+	conn, err := tls.DialWithDialer(&net.Dialer{Timeout: 2 * time.Second}, "tcp", addr, config)
+	if err != nil {
+		t.Fatalf("TLS handshake failed: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+func serialOf(conn *tls.Conn) int64 {
+	return conn.ConnectionState().PeerCertificates[0].SerialNumber.Int64()
+}
+
+func TestTLSHandshake(t *testing.T) {
+	ca := newTestCA(t)
+	certFile, keyFile := writeServerCert(t, ca, t.TempDir(), 100, time.Now())
+
+	tlsConfig, err := newTLSConfig(config.TLSConfig{CertFile: certFile, KeyFile: keyFile, MinVersion: "1.2"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr, results := startTLSListener(t, &MatchServer{tlsConfig: tlsConfig})
+
+	conn := dialTLS(t, addr, &tls.Config{RootCAs: ca.pool(), ServerName: "localhost"})
+	result := <-results
+	if result.err != nil {
+		t.Fatalf("server handshake failed: %v", result.err)
+	}
+	if result.client.ServiceName != "" {
+		t.Fatalf("client without a certificate got service name %q", result.client.ServiceName)
+	}
+
+	// 핸드셰이크 이후 데이터가 오가는지 확인
+	go conn.Write([]byte(`{"type":"ping"}`))
+	buffer := make([]byte, 64)
+	n, err := result.conn.Read(buffer)
+	if err != nil || string(buffer[:n]) != `{"type":"ping"}` {
+		t.Fatalf("read %q, err = %v", buffer[:n], err)
+	}
+}
+
+func TestTLSRejectsPlaintext(t *testing.T) {
+	ca := newTestCA(t)
+	certFile, keyFile := writeServerCert(t, ca, t.TempDir(), 100, time.Now())
+
+	tlsConfig, err := newTLSConfig(config.TLSConfig{CertFile: certFile, KeyFile: keyFile, MinVersion: "1.2"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr, results := startTLSListener(t, &MatchServer{tlsConfig: tlsConfig})
+
+	conn, err := net.Dial("tcp", addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	if _, err := conn.Write([]byte(`{"type":"auth","data":{"token":"t"}}`)); err != nil {
+		t.Fatal(err)
+	}
+
+	result := <-results
+	if result.err == nil {
+		t.Fatal("plaintext message should fail the TLS handshake")
+	}
+}
+
+func TestCertReloaderKeepsExistingConnections(t *testing.T) {
+	ca := newTestCA(t)
+	dir := t.TempDir()
+	initial := time.Now().Add(-time.Minute)
+	certFile, keyFile := writeServerCert(t, ca, dir, 100, initial)
+
+	reloader, err := newCertReloader(certFile, keyFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := &MatchServer{tlsConfig: &tls.Config{GetCertificate: reloader.GetCertificate}}
+	addr, results := startTLSListener(t, s)
+	clientConfig := &tls.Config{RootCAs: ca.pool(), ServerName: "localhost"}
+
+	oldConn := dialTLS(t, addr, clientConfig)
+	oldResult := <-results
+	if oldResult.err != nil {
+		t.Fatal(oldResult.err)
+	}
+	if serialOf(oldConn) != 100 {
+		t.Fatalf("initial serial = %d", serialOf(oldConn))
+	}
+
+	// 파일이 바뀌지 않았으면 다시 읽지 않음
+	if changed, err := reloader.reload(); err != nil || changed {
+		t.Fatalf("reload without changes = (%v, %v)", changed, err)
+	}
+
+	writeServerCert(t, ca, dir, 200, time.Now())
+	if changed, err := reloader.reload(); err != nil || !changed {
+		t.Fatalf("reload after rewrite = (%v, %v)", changed, err)
+	}
+
+	newConn := dialTLS(t, addr, clientConfig)
+	if result := <-results; result.err != nil {
+		t.Fatal(result.err)
+	}
+	if serialOf(newConn) != 200 {
+		t.Fatalf("new connection serial = %d, want reloaded certificate", serialOf(newConn))
+	}
+
+	// 기존 연결은 이전 인증서로 계속 통신
+	go oldConn.Write([]byte("still open"))
+	buffer := make([]byte, 64)
+	n, err := oldResult.conn.Read(buffer)
+	if err != nil || string(buffer[:n]) != "still open" {
+		t.Fatalf("existing connection read %q, err = %v", buffer[:n], err)
+	}
+
+	// 깨진 파일로 바뀌면 기존 인증서 유지
+	if err := os.WriteFile(certFile, []byte("broken"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	os.Chtimes(certFile, time.Now().Add(time.Minute), time.Now().Add(time.Minute))
+	if _, err := reloader.reload(); err == nil {
+		t.Fatal("broken certificate should fail to reload")
+	}
+	if cert, _ := reloader.GetCertificate(nil); cert == nil {
+		t.Fatal("previous certificate should be kept")
+	}
+}
+
+func TestCertReloaderWatchStops(t *testing.T) {
+	ca := newTestCA(t)
+	certFile, keyFile := writeServerCert(t, ca, t.TempDir(), 100, time.Now())
+	reloader, err := newCertReloader(certFile, keyFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		reloader.watch(time.Millisecond, stop)
+	}()
+
+	close(stop)
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("watch should return after stop is closed")
+	}
+}
+
+func TestShutdownStopsCertReload(t *testing.T) {
+	s := &MatchServer{stop: make(chan struct{}), conns: make(map[*Client]struct{})}
+	if err := s.Shutdown(context.Background(), 0); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-s.stop:
+	default:
+		t.Fatal("Shutdown should close the stop channel")
+	}
+	// 두 번 호출해도 패닉 없음
+	if err := s.Shutdown(context.Background(), 0); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestVerifyClientCertIfGiven(t *testing.T) {
+	ca := newTestCA(t)
+	dir := t.TempDir()
+	certFile, keyFile := writeServerCert(t, ca, dir, 100, time.Now())
+	caFile := filepath.Join(dir, "client-ca.pem")
+	if err := os.WriteFile(caFile, ca.pem, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tlsConfig, err := newTLSConfig(config.TLSConfig{CertFile: certFile, KeyFile: keyFile, MinVersion: "1.3", ClientCAFile: caFile}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tlsConfig.ClientAuth != tls.VerifyClientCertIfGiven {
+		t.Fatalf("ClientAuth = %v", tlsConfig.ClientAuth)
+	}
+	addr, results := startTLSListener(t, &MatchServer{tlsConfig: tlsConfig})
+
+	clientCertPEM, clientKeyPEM := ca.issue(t, "match-worker", 300, x509.ExtKeyUsageClientAuth)
+	clientCert, err := tls.X509KeyPair(clientCertPEM, clientKeyPEM)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 인증서를 제시한 내부 서버는 CN이 서비스 이름
+	conn := dialTLS(t, addr, &tls.Config{RootCAs: ca.pool(), ServerName: "localhost", Certificates: []tls.Certificate{clientCert}})
+	result := <-results
+	if result.err != nil {
+		t.Fatal(result.err)
+	}
+	if result.client.ServiceName != "match-worker" {
+		t.Fatalf("ServiceName = %q, want match-worker", result.client.ServiceName)
+	}
+	conn.Close()
+
+	// 인증서 없는 일반 클라이언트도 접속 가능
+	dialTLS(t, addr, &tls.Config{RootCAs: ca.pool(), ServerName: "localhost"})
+	if result := <-results; result.err != nil || result.client.ServiceName != "" {
+		t.Fatalf("client without certificate: err = %v, service = %q", result.err, result.client.ServiceName)
+	}
+
+	// 다른 CA가 서명한 인증서는 거절
+	otherCA := newTestCA(t)
+	otherCertPEM, otherKeyPEM := otherCA.issue(t, "intruder", 400, x509.ExtKeyUsageClientAuth)
+	otherCert, err := tls.X509KeyPair(otherCertPEM, otherKeyPEM)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rejected, err := tls.Dial("tcp", addr, &tls.Config{RootCAs: ca.pool(), ServerName: "localhost", Certificates: []tls.Certificate{otherCert}})
+	if err == nil {
+		defer rejected.Close()
+	}
+	if result := <-results; result.err == nil {
+		t.Fatal("certificate from an unknown CA should be rejected")
+	}
+}